@@ -0,0 +1,238 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned immediately while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("dadago: circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker.
+type CircuitState int
+
+const (
+	// StateClosed calls pass through, failures are counted.
+	StateClosed CircuitState = iota
+	// StateOpen calls fail immediately with ErrCircuitOpen until the cool-down ends.
+	StateOpen
+	// StateHalfOpen a limited number of trial calls pass through to probe the gateway.
+	StateHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig is the configuration of CircuitBreaker.
+type CircuitBreakerConfig struct {
+	FailureRatio     float64       // 触发熔断的失败率，默认 0.5
+	MinRequests      int           // 统计窗口内触发熔断的最少请求数，默认 10
+	Window           time.Duration // 统计窗口，默认 10s
+	CoolDown         time.Duration // 熔断后进入半开状态的冷却时间，默认 30s
+	HalfOpenRequests int           // 半开状态允许的并发试探请求数，也是恢复关闭所需的成功次数，默认 1
+	// IsFailure reports whether err means the gateway is degraded, IsGatewayFailure is used when nil.
+	IsFailure func(err error) bool
+	// OnStateChange is called after the state changed.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker is a circuit breaker around the gateway, it is safe for concurrent use.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trials      int // 半开状态进行中的试探请求数
+	successes   int // 半开状态成功的试探请求数
+	generation  uint64
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = 0.5
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = 10
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 10 * time.Second
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = 30 * time.Second
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = IsGatewayFailure
+	}
+	return &CircuitBreaker{cfg: cfg, windowStart: time.Now()}
+}
+
+// IsGatewayFailure reports whether err means the gateway is unavailable: a network error,
+// a timeout, a 5xx http status or a body that is not JSON. A failed return code is not a gateway failure.
+func IsGatewayFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if _, ok := asAPIError(err); ok {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// State returns the current state.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	from, changed := b.advance(time.Now())
+	to := b.state
+	b.mu.Unlock()
+	if changed {
+		b.onStateChange(from, to)
+	}
+	return to
+}
+
+// allow reports whether a call may pass, it returns ErrCircuitOpen when it may not.
+// Every allowed call must be followed by record with the returned generation.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	b.mu.Lock()
+	from, changed := b.advance(time.Now())
+	var err error
+	switch b.state {
+	case StateOpen:
+		err = ErrCircuitOpen
+	case StateHalfOpen:
+		if b.trials >= b.cfg.HalfOpenRequests {
+			err = ErrCircuitOpen
+		} else {
+			b.trials++
+		}
+	}
+	to, generation := b.state, b.generation
+	b.mu.Unlock()
+	if changed {
+		b.onStateChange(from, to)
+	}
+	return generation, err
+}
+
+// record records the result of an allowed call, results of a previous state are ignored.
+// A trial canceled by its caller tells nothing about the gateway, it only releases its slot.
+func (b *CircuitBreaker) record(generation uint64, err error) {
+	canceled := errors.Is(err, context.Canceled)
+	failed := !canceled && b.cfg.IsFailure(err)
+	b.mu.Lock()
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	from := b.state
+	now := time.Now()
+	switch b.state {
+	case StateHalfOpen:
+		b.trials--
+		switch {
+		case canceled:
+		case failed:
+			b.setState(StateOpen, now)
+		default:
+			if b.successes++; b.successes >= b.cfg.HalfOpenRequests {
+				b.setState(StateClosed, now)
+			}
+		}
+	case StateClosed:
+		if now.Sub(b.windowStart) > b.cfg.Window {
+			b.resetWindow(now)
+		}
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+			b.setState(StateOpen, now)
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	if from != to {
+		b.onStateChange(from, to)
+	}
+}
+
+// advance moves an open breaker to half-open after the cool-down, it must be called with the lock held.
+func (b *CircuitBreaker) advance(now time.Time) (from CircuitState, changed bool) {
+	from = b.state
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.cfg.CoolDown {
+		b.setState(StateHalfOpen, now)
+		return from, true
+	}
+	return from, false
+}
+
+// setState changes the state, it must be called with the lock held.
+func (b *CircuitBreaker) setState(state CircuitState, now time.Time) {
+	b.state = state
+	b.generation++
+	b.trials = 0
+	b.successes = 0
+	b.resetWindow(now)
+	if state == StateOpen {
+		b.openedAt = now
+	}
+}
+
+// resetWindow starts a new statistic window, it must be called with the lock held.
+func (b *CircuitBreaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+// onStateChange calls the state change callback.
+func (b *CircuitBreaker) onStateChange(from, to CircuitState) {
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
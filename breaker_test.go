@@ -0,0 +1,214 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testCoolDown = 20 * time.Millisecond
+
+var (
+	errGateway = &HTTPError{StatusCode: http.StatusBadGateway}
+	errCode    = &APIError{Code: 2006}
+)
+
+// transition is a state change of the breaker.
+type transition struct {
+	from, to CircuitState
+}
+
+// newTestBreaker returns a breaker opening after 2 failures of 4 calls and the list of its state changes.
+func newTestBreaker(halfOpen int) (*CircuitBreaker, *[]transition) {
+	var changes []transition
+	b := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRatio:     0.5,
+		MinRequests:      4,
+		Window:           time.Minute,
+		CoolDown:         testCoolDown,
+		HalfOpenRequests: halfOpen,
+		OnStateChange: func(from, to CircuitState) {
+			changes = append(changes, transition{from, to})
+		},
+	})
+	return b, &changes
+}
+
+// callBreaker passes a call with the result err through the breaker.
+func callBreaker(t *testing.T, b *CircuitBreaker, err error) {
+	t.Helper()
+	generation, allowErr := b.allow()
+	if allowErr != nil {
+		t.Fatalf("call rejected in state %s: %v", b.State(), allowErr)
+	}
+	b.record(generation, err)
+}
+
+// openBreaker opens the breaker and waits for the cool-down.
+func openBreaker(t *testing.T, b *CircuitBreaker) {
+	t.Helper()
+	for _, err := range []error{nil, errGateway, nil, errGateway} {
+		callBreaker(t, b, err)
+	}
+	if s := b.State(); s != StateOpen {
+		t.Fatalf("state %s, want open", s)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() while open = %v, want ErrCircuitOpen", err)
+	}
+	time.Sleep(testCoolDown + 5*time.Millisecond)
+	if s := b.State(); s != StateHalfOpen {
+		t.Fatalf("state %s after the cool-down, want half-open", s)
+	}
+}
+
+func TestCircuitBreakerOpensOnGatewayFailures(t *testing.T) {
+	b, _ := newTestBreaker(1)
+	// Return codes are the business of the caller, they do not open the breaker.
+	for i := 0; i < 8; i++ {
+		callBreaker(t, b, errCode)
+	}
+	callBreaker(t, b, errGateway)
+	callBreaker(t, b, errGateway)
+	if s := b.State(); s != StateClosed {
+		t.Fatalf("state %s after return code errors, want closed", s)
+	}
+	// 8 failures of 16 calls reach the ratio.
+	for i := 0; i < 6; i++ {
+		callBreaker(t, b, errGateway)
+	}
+	if s := b.State(); s != StateOpen {
+		t.Fatalf("state %s, want open", s)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		halfOpen int
+		results  []error
+		want     CircuitState
+		changes  []transition
+	}{
+		{
+			name:     "one success closes",
+			halfOpen: 1,
+			results:  []error{nil},
+			want:     StateClosed,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}, {StateHalfOpen, StateClosed}},
+		},
+		{
+			name:     "failure reopens",
+			halfOpen: 1,
+			results:  []error{errGateway},
+			want:     StateOpen,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}, {StateHalfOpen, StateOpen}},
+		},
+		{
+			name:     "canceled probe keeps half-open",
+			halfOpen: 1,
+			results:  []error{context.Canceled, fmt.Errorf("call: %w", context.Canceled)},
+			want:     StateHalfOpen,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}},
+		},
+		{
+			name:     "canceled probe then success closes",
+			halfOpen: 1,
+			results:  []error{context.Canceled, nil},
+			want:     StateClosed,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}, {StateHalfOpen, StateClosed}},
+		},
+		{
+			name:     "needs every success",
+			halfOpen: 3,
+			results:  []error{nil, nil},
+			want:     StateHalfOpen,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}},
+		},
+		{
+			name:     "sequential successes close",
+			halfOpen: 3,
+			results:  []error{nil, context.Canceled, nil, nil},
+			want:     StateClosed,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}, {StateHalfOpen, StateClosed}},
+		},
+		{
+			name:     "last failure reopens",
+			halfOpen: 3,
+			results:  []error{nil, nil, errGateway},
+			want:     StateOpen,
+			changes:  []transition{{StateClosed, StateOpen}, {StateOpen, StateHalfOpen}, {StateHalfOpen, StateOpen}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, changes := newTestBreaker(tt.halfOpen)
+			openBreaker(t, b)
+			for _, err := range tt.results {
+				callBreaker(t, b, err)
+			}
+			if s := b.State(); s != tt.want {
+				t.Errorf("state %s, want %s", s, tt.want)
+			}
+			if fmt.Sprint(*changes) != fmt.Sprint(tt.changes) {
+				t.Errorf("state changes %v, want %v", *changes, tt.changes)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerHalfOpenConcurrency(t *testing.T) {
+	b, _ := newTestBreaker(2)
+	openBreaker(t, b)
+
+	first, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("third concurrent probe: %v, want ErrCircuitOpen", err)
+	}
+	b.record(first, nil)
+	if s := b.State(); s != StateHalfOpen {
+		t.Fatalf("state %s after one success of two, want half-open", s)
+	}
+	// The finished probe released its slot.
+	third, err := b.allow()
+	if err != nil {
+		t.Fatalf("probe after a finished one: %v", err)
+	}
+	b.record(second, nil)
+	if s := b.State(); s != StateClosed {
+		t.Fatalf("state %s after two successes, want closed", s)
+	}
+	// The result of the previous state is ignored.
+	b.record(third, errGateway)
+	if s := b.State(); s != StateClosed {
+		t.Errorf("state %s after a stale failure, want closed", s)
+	}
+}
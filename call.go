@@ -0,0 +1,95 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// merchantAPIPrefix is the path prefix of the merchant APIs, these APIs are signed without source_id.
+const merchantAPIPrefix = "/merchantApi/"
+
+// Call invokes any API of the gateway, including the ones not wrapped by the Client.
+// The body is encoded as the business parameters of the request, a nil body sends an empty body.
+// The response is decoded into out when it is not nil, out is usually a *domain.Response[T].
+// A failed response is returned as *APIError.
+func (c *Client) Call(ctx context.Context, path string, body any, out any) error {
+	inv := &Invocation{
+		Path:     path,
+		Request:  body,
+		Envelope: c.newRequest(),
+		Response: out,
+	}
+	// The interceptors see the signed envelope of the call.
+	if err := c.encode(inv); err != nil {
+		return err
+	}
+	return c.handler(ctx, inv)
+}
+
+// encode encodes the business parameters of inv into its envelope and signs it.
+func (c *Client) encode(inv *Invocation) (err error) {
+	inv.Envelope.Body = ""
+	if inv.Request != nil {
+		if inv.Envelope.Body, err = sonic.MarshalString(inv.Request); err != nil {
+			return err
+		}
+	}
+	if strings.HasPrefix(inv.Path, merchantAPIPrefix) {
+		inv.Envelope.SourceID = ""
+	}
+	c.generateTimestamp(inv.Envelope)
+	c.md5Sign(inv.Envelope)
+	return nil
+}
+
+// invoke is the innermost handler of the interceptor chain, it sends the request and decodes the response.
+// The envelope is encoded again as an interceptor may have changed the request.
+func (c *Client) invoke(ctx context.Context, inv *Invocation) (err error) {
+	if err = c.encode(inv); err != nil {
+		return err
+	}
+	if inv.RawResponse, err = c.doRetry(ctx, inv); err != nil {
+		return err
+	}
+	if inv.Response == nil {
+		return nil
+	}
+	if err = sonic.Unmarshal(inv.RawResponse, inv.Response); err != nil {
+		return &DecodeError{Path: inv.Path, Body: inv.RawResponse, Err: err}
+	}
+	return nil
+}
+
+// Invoke invokes the API at path with req and decodes the response into a new Resp.
+// A nil req sends an empty body.
+func Invoke[Req, Resp any](ctx context.Context, c *Client, path string, req *Req) (*Resp, error) {
+	var body any
+	if req != nil {
+		body = req
+	}
+	resp := new(Resp)
+	if err := c.Call(ctx, path, body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
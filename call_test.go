@@ -0,0 +1,93 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+const callSourceID = "73753"
+
+func TestCallUnwrappedPath(t *testing.T) {
+	rec := &recordingTransport{next: stubTransport{}}
+	c := newOfflineClient(t, rec, dadago.WithSourceID(callSourceID))
+
+	const path = "/api/order/newFeature"
+	body := map[string]any{"order_id": "call-1", "flag": true}
+	var out domain.Response[map[string]any]
+	if err := c.Call(context.Background(), path, body, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Status != "success" {
+		t.Errorf("decoded %+v, want the success response", out)
+	}
+	sent := rec.attempts(path)
+	if len(sent) != 1 {
+		t.Fatalf("%d requests to %s, want 1", len(sent), path)
+	}
+	env := sent[0]
+	if env.Body != `{"flag":true,"order_id":"call-1"}` || env.SourceID != callSourceID {
+		t.Errorf("envelope %+v, want the encoded body and the source id", env)
+	}
+	if want := dadago.Sign(offlineSecret, &env); env.Signature != want {
+		t.Errorf("signature %q, want %q", env.Signature, want)
+	}
+
+	// A nil body and a nil out send an empty body and skip decoding.
+	if err := c.Call(context.Background(), path, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if sent = rec.attempts(path); len(sent) != 2 || sent[1].Body != "" {
+		t.Errorf("envelopes %+v, want an empty body", sent)
+	}
+}
+
+func TestCallMerchantAPIWithoutSourceID(t *testing.T) {
+	rec := &recordingTransport{next: stubTransport{}}
+	c := newOfflineClient(t, rec, dadago.WithSourceID(callSourceID))
+	ctx := context.Background()
+
+	const merchantPath = "/merchantApi/merchant/add"
+	if _, err := dadago.Invoke[domain.MerchantCreateRequest, domain.MerchantCreateResponse](ctx, c, merchantPath, &domain.MerchantCreateRequest{Mobile: "13800000000"}); err != nil {
+		t.Fatal(err)
+	}
+	// The source id of the client is kept for the other APIs.
+	if _, err := c.QueryBalance(ctx, &domain.QueryBalanceRequest{Category: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.sent) != 2 {
+		t.Fatalf("%d requests sent, want 2", len(rec.sent))
+	}
+	merchant, balance := rec.sent[0], rec.sent[1]
+	if !strings.HasSuffix(merchant.url, merchantPath) || merchant.envelope.SourceID != "" {
+		t.Errorf("merchant request to %s with source id %q, want none", merchant.url, merchant.envelope.SourceID)
+	}
+	if want := dadago.Sign(offlineSecret, &merchant.envelope); merchant.envelope.Signature != want {
+		t.Errorf("merchant signature %q, want %q signed without source id", merchant.envelope.Signature, want)
+	}
+	if balance.envelope.SourceID != callSourceID {
+		t.Errorf("balance request with source id %q, want %q", balance.envelope.SourceID, callSourceID)
+	}
+}
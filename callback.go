@@ -0,0 +1,188 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// ErrCallbackSignature is returned when the signature of a callback does not match.
+var ErrCallbackSignature = errors.New("dadago: callback signature error")
+
+// CallbackHandler handles an order status callback, returning an error makes the gateway resend it.
+type CallbackHandler func(ctx context.Context, notify *domain.OrdersAsyncResponse) error
+
+// CallbackReceiver receives the order status callbacks posted to the callback url.
+// It verifies the signature and dispatches the callback to the handler of the order status,
+// callbacks without a handler are acknowledged and dropped.
+// See: http://newopen.imdada.cn/#/development/file/order
+type CallbackReceiver struct {
+	OnWaitAccept   CallbackHandler // 待接单＝1
+	OnAccepted     CallbackHandler // 待取货＝2
+	OnFetched      CallbackHandler // 配送中＝3
+	OnFinished     CallbackHandler // 已完成＝4
+	OnCancelled    CallbackHandler // 已取消＝5
+	OnExpired      CallbackHandler // 已过期＝7
+	OnAppointed    CallbackHandler // 已追加待接单=8
+	OnReturning    CallbackHandler // 妥投异常之物品返回中=9
+	OnReturned     CallbackHandler // 妥投异常之物品返回完成=10
+	OnArrived      CallbackHandler // 骑士到店=100
+	OnCreateFailed CallbackHandler // 创建达达运单失败=1000
+	OnOther        CallbackHandler // 其他状态
+
+	// Messages receives the messages posted to the same callback url, e.g. the rider cancellation requests.
+	Messages *MessageReceiver
+
+	log     Logger
+	metrics Metrics
+}
+
+// callbackReply is the reply of a callback.
+type callbackReply struct {
+	Status string `json:"status"`
+	Msg    string `json:"msg,omitempty"`
+}
+
+// NewCallbackReceiver creates a CallbackReceiver sharing the logger and the metrics of the client.
+func (c *Client) NewCallbackReceiver() *CallbackReceiver {
+	return &CallbackReceiver{log: c.log, metrics: c.op.Metrics}
+}
+
+// Handle decodes, verifies and dispatches a callback body, it returns the http status and the reply body.
+func (r *CallbackReceiver) Handle(ctx context.Context, body []byte) (int, []byte) {
+	if r.Messages != nil {
+		var msg struct {
+			MessageType int `json:"messageType"`
+		}
+		if err := sonic.Unmarshal(body, &msg); err == nil && msg.MessageType != 0 {
+			return r.Messages.Handle(ctx, body)
+		}
+	}
+	var notify domain.OrdersAsyncResponse
+	if err := sonic.Unmarshal(body, &notify); err != nil {
+		return r.reply(ctx, http.StatusBadRequest, err)
+	}
+	if CallbackSignature(notify.UpdateTime, notify.ClientID, notify.OrderID) != notify.Signature {
+		return r.reply(ctx, http.StatusUnauthorized, ErrCallbackSignature)
+	}
+	if r.metrics != nil {
+		r.metrics.ObserveCallback(int(notify.OrderStatus))
+	}
+	if r.log != nil {
+		r.log.CtxDebugf(ctx, "callback data: %+v", notify)
+	}
+	if h := r.handler(notify.OrderStatus); h != nil {
+		if err := h(ctx, &notify); err != nil {
+			return r.reply(ctx, http.StatusInternalServerError, err)
+		}
+	}
+	return r.reply(ctx, http.StatusOK, nil)
+}
+
+// handler returns the handler of the order status.
+func (r *CallbackReceiver) handler(status domain.OrderStatus) CallbackHandler {
+	switch status {
+	case domain.OrderStatusWaitAccept:
+		return r.OnWaitAccept
+	case domain.OrderStatusWaitFetch:
+		return r.OnAccepted
+	case domain.OrderStatusDelivering:
+		return r.OnFetched
+	case domain.OrderStatusFinished:
+		return r.OnFinished
+	case domain.OrderStatusCancelled:
+		return r.OnCancelled
+	case domain.OrderStatusExpired:
+		return r.OnExpired
+	case domain.OrderStatusAppointed:
+		return r.OnAppointed
+	case domain.OrderStatusReturning:
+		return r.OnReturning
+	case domain.OrderStatusReturned:
+		return r.OnReturned
+	case domain.OrderStatusArrived:
+		return r.OnArrived
+	case domain.OrderStatusCreateFailed:
+		return r.OnCreateFailed
+	default:
+		return r.OnOther
+	}
+}
+
+// reply encodes the reply of a callback.
+func (r *CallbackReceiver) reply(ctx context.Context, code int, err error) (int, []byte) {
+	reply := callbackReply{Status: "ok"}
+	if err != nil {
+		reply = callbackReply{Status: "fail", Msg: err.Error()}
+		if r.log != nil {
+			r.log.CtxErrorf(ctx, "callback failed: %v", err)
+		}
+	}
+	data, _ := sonic.Marshal(&reply)
+	return code, data
+}
+
+// BodyHandler handles a body posted by the gateway, it returns the http status and the reply body.
+// CallbackReceiver and MessageReceiver are BodyHandlers.
+type BodyHandler interface {
+	Handle(ctx context.Context, body []byte) (int, []byte)
+}
+
+// ServeHTTP implements http.Handler.
+func (r *CallbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serveHTTP(r, w, req)
+}
+
+// HertzHandler returns the hertz handler of the receiver.
+func (r *CallbackReceiver) HertzHandler() app.HandlerFunc {
+	return hertzHandler(r)
+}
+
+// serveHTTP serves the body handler over net/http.
+func serveHTTP(h BodyHandler, w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	code, data := h.Handle(req.Context(), body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+// hertzHandler serves the body handler over hertz.
+func hertzHandler(h BodyHandler) app.HandlerFunc {
+	return func(ctx context.Context, rc *app.RequestContext) {
+		code, data := h.Handle(ctx, rc.Request.Body())
+		rc.Data(code, "application/json", data)
+	}
+}
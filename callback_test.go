@@ -0,0 +1,117 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+// callbackReply is the reply of a callback.
+type callbackReply struct {
+	Status string `json:"status"`
+	Msg    string `json:"msg"`
+}
+
+// signedCallback returns a signed callback of the order.
+func signedCallback(orderID string, status domain.OrderStatus) *domain.OrdersAsyncResponse {
+	notify := &domain.OrdersAsyncResponse{ClientID: "1001", OrderID: orderID, OrderStatus: status, UpdateTime: time.Now().Unix()}
+	notify.Signature = dadago.CallbackSignature(notify.UpdateTime, notify.ClientID, notify.OrderID)
+	return notify
+}
+
+func TestCallbackReceiverReplies(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	var handled []string
+	r := c.NewCallbackReceiver()
+	r.OnFinished = func(_ context.Context, notify *domain.OrdersAsyncResponse) error {
+		handled = append(handled, notify.OrderID)
+		return nil
+	}
+
+	forged := signedCallback("forged", domain.OrderStatusFinished)
+	forged.OrderID = "other"
+	valid, _ := sonic.Marshal(signedCallback("valid", domain.OrderStatusFinished))
+	badSignature, _ := sonic.Marshal(forged)
+	tests := []struct {
+		name   string
+		body   string
+		code   int
+		status string
+		msg    string
+	}{
+		{"valid", string(valid), http.StatusOK, "ok", ""},
+		{"bad signature", string(badSignature), http.StatusUnauthorized, "fail", dadago.ErrCallbackSignature.Error()},
+		{"bad body", `{"order_id":`, http.StatusBadRequest, "fail", ""},
+		{"bad field", `{"order_id":"x","order_status":"finished"}`, http.StatusBadRequest, "fail", ""},
+	}
+	for _, tt := range tests {
+		code, data := r.Handle(context.Background(), []byte(tt.body))
+		var reply callbackReply
+		if err := sonic.Unmarshal(data, &reply); err != nil {
+			t.Fatalf("%s: reply %s: %v", tt.name, data, err)
+		}
+		if code != tt.code || reply.Status != tt.status {
+			t.Errorf("%s: reply %d %s, want %d %s", tt.name, code, data, tt.code, tt.status)
+		}
+		if tt.msg != "" && reply.Msg != tt.msg {
+			t.Errorf("%s: reply message %q, want %q", tt.name, reply.Msg, tt.msg)
+		}
+		if tt.status == "fail" && reply.Msg == "" {
+			t.Errorf("%s: reply without a message", tt.name)
+		}
+	}
+	if len(handled) != 1 || handled[0] != "valid" {
+		t.Errorf("handled %v, want only the valid callback", handled)
+	}
+}
+
+func TestCallbackReceiverHandlerError(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	r := c.NewCallbackReceiver()
+	r.OnAccepted = func(context.Context, *domain.OrdersAsyncResponse) error {
+		return errors.New("store unavailable")
+	}
+	body, _ := sonic.Marshal(signedCallback("retry", domain.OrderStatusWaitFetch))
+	// A failed handler makes the gateway resend the callback.
+	if code, data := r.Handle(context.Background(), body); code != http.StatusInternalServerError || !strings.Contains(string(data), "store unavailable") {
+		t.Errorf("reply %d %s, want 500 with the error", code, data)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	notify := signedCallback("verify", domain.OrderStatusFinished)
+	if err := c.VerifySignature(context.Background(), notify.UpdateTime, notify.ClientID, notify.OrderID, notify.Signature); err != nil {
+		t.Errorf("VerifySignature() = %v", err)
+	}
+	err := c.VerifySignature(context.Background(), notify.UpdateTime+1, notify.ClientID, notify.OrderID, notify.Signature)
+	if !errors.Is(err, dadago.ErrCallbackSignature) {
+		t.Errorf("VerifySignature() of another time = %v, want ErrCallbackSignature", err)
+	}
+}
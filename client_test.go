@@ -0,0 +1,135 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+// TestClientConcurrentCalls shares one Client across goroutines, run it with -race.
+// Every call must carry its own body and a signature of that body.
+func TestClientConcurrentCalls(t *testing.T) {
+	const (
+		goroutines = 16
+		calls      = 20
+	)
+	var (
+		mu      sync.Mutex
+		invalid []string
+	)
+	// check runs after the envelope of the call is signed and sent.
+	check := func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		err := next(ctx, inv)
+		want, _ := ctx.Value(originKey{}).(string)
+		env := inv.Envelope
+		if want != "" && !strings.Contains(env.Body, `"origin_id":"`+want+`"`) {
+			mu.Lock()
+			invalid = append(invalid, fmt.Sprintf("%s: body of another call: %s", want, env.Body))
+			mu.Unlock()
+		}
+		if dadago.Sign(dadatestSecret, env) != env.Signature {
+			mu.Lock()
+			invalid = append(invalid, fmt.Sprintf("%s: signature %s does not match the body", inv.Path, env.Signature))
+			mu.Unlock()
+		}
+		return err
+	}
+	s, c := newTestClient(t, []dadatest.Option{dadatest.WithAppSecret(dadatestSecret), dadatest.WithBalance(domain.Yuan(100000))},
+		dadago.WithInterceptors(check))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*calls)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < calls; i++ {
+				originID := fmt.Sprintf("race-%d-%d", g, i)
+				ctx := context.WithValue(context.Background(), originKey{}, originID)
+				if _, err := c.CreateOrder(ctx, newOrder(originID)); err != nil {
+					errs <- fmt.Errorf("create %s: %w", originID, err)
+					continue
+				}
+				if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+					errs <- fmt.Errorf("query balance: %w", err)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	for _, msg := range invalid {
+		t.Error(msg)
+	}
+	for g := 0; g < goroutines; g++ {
+		for i := 0; i < calls; i++ {
+			originID := fmt.Sprintf("race-%d-%d", g, i)
+			o, ok := s.Order(originID)
+			if !ok {
+				t.Errorf("order %s not created", originID)
+				continue
+			}
+			if want := "收件人" + originID; o.Request.ReceiverName != want {
+				t.Errorf("order %s has receiver %q, want %q", originID, o.Request.ReceiverName, want)
+			}
+		}
+	}
+}
+
+// TestClientConcurrentCallsWithPolicies runs concurrent calls through the retry policy and the rate limiter.
+func TestClientConcurrentCallsWithPolicies(t *testing.T) {
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{Global: dadago.RateLimit{QPS: 1000, Burst: 50}})
+	_, c := newTestClient(t, nil,
+		dadago.WithRetryPolicy(dadago.DefaultRetryPolicy()),
+		dadago.WithRateLimiter(limiter),
+	)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				resp, err := c.QueryShop(context.Background(), &domain.ShopQueryRequest{OriginShopID: testShopNo})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if resp.Result.OriginShopID != testShopNo {
+					t.Errorf("shop %q, want %q", resp.Result.OriginShopID, testShopNo)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type originKey struct{}
+
+const dadatestSecret = "race-test-secret"
@@ -0,0 +1,176 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"errors"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// ErrorClass is the classification of a return code of the gateway.
+type ErrorClass int
+
+const (
+	// ClassUnknown the code is not in the catalogue.
+	ClassUnknown ErrorClass = iota
+	// ClassRetryable a temporary failure, the same request may succeed later.
+	ClassRetryable
+	// ClassCaller the request is wrong and must be fixed by the caller.
+	ClassCaller
+	// ClassAccount the merchant account is misconfigured, out of balance or disabled.
+	ClassAccount
+)
+
+// String returns the name of the class.
+func (c ErrorClass) String() string {
+	switch c {
+	case ClassRetryable:
+		return "retryable"
+	case ClassCaller:
+		return "caller"
+	case ClassAccount:
+		return "account"
+	default:
+		return "unknown"
+	}
+}
+
+// Sentinel errors of the known return codes, match them with errors.Is.
+// Both *APIError and the *domain.ResponseError of Response.Err match them.
+var (
+	ErrSystem               = newCodeError(-1, "dadago: system error")
+	ErrInvalidAppKey        = newCodeError(2001, "dadago: invalid app_key")
+	ErrSignature            = newCodeError(2003, "dadago: signature verification failed")
+	ErrInvalidSourceID      = newCodeError(2004, "dadago: invalid source_id")
+	ErrTooManyRequests      = newCodeError(2005, "dadago: too many requests")
+	ErrInvalidParam         = newCodeError(2006, "dadago: invalid parameter")
+	ErrTimestampExpired     = newCodeError(2008, "dadago: timestamp expired")
+	ErrInsufficientBalance  = newCodeError(2011, "dadago: insufficient balance")
+	ErrShopNotFound         = newCodeError(2012, "dadago: shop not found")
+	ErrCityNotSupported     = newCodeError(2014, "dadago: city not supported")
+	ErrOrderNotCancellable  = newCodeError(2062, "dadago: order can not be cancelled")
+	ErrOrderNotFound        = newCodeError(2076, "dadago: order not found")
+	ErrDuplicateOriginID    = newCodeError(2105, "dadago: duplicate origin_id")
+	ErrMerchantNotAvailable = newCodeError(2402, "dadago: merchant not available")
+)
+
+// codeError is the sentinel error of a return code.
+type codeError struct {
+	code int
+	msg  string
+}
+
+// newCodeError creates the sentinel error of code.
+func newCodeError(code int, msg string) error {
+	return &codeError{code: code, msg: msg}
+}
+
+// Error implements the error interface.
+func (e *codeError) Error() string {
+	return e.msg
+}
+
+// ResponseCode returns the return code of the sentinel, *domain.ResponseError matches it by this code.
+func (e *codeError) ResponseCode() int {
+	return e.code
+}
+
+// CodeInfo describes a known return code of the gateway.
+type CodeInfo struct {
+	Code   int        // 返回码
+	Err    error      // 对应的错误
+	Desc   string     // 英文描述
+	DescZh string     // 中文描述
+	Class  ErrorClass // 错误分类
+}
+
+// codes is the catalogue of the known return codes.
+// See: http://newopen.imdada.cn/#/development/file/code
+var codes = map[int]*CodeInfo{
+	-1:   {Code: -1, Err: ErrSystem, Desc: "system error", DescZh: "系统异常", Class: ClassRetryable},
+	2001: {Code: 2001, Err: ErrInvalidAppKey, Desc: "invalid app_key", DescZh: "app_key无效", Class: ClassAccount},
+	2003: {Code: 2003, Err: ErrSignature, Desc: "signature verification failed", DescZh: "签名错误", Class: ClassCaller},
+	2004: {Code: 2004, Err: ErrInvalidSourceID, Desc: "invalid source_id", DescZh: "source_id不合法", Class: ClassAccount},
+	2005: {Code: 2005, Err: ErrTooManyRequests, Desc: "too many requests", DescZh: "请求过于频繁", Class: ClassRetryable},
+	2006: {Code: 2006, Err: ErrInvalidParam, Desc: "invalid parameter", DescZh: "参数格式错误", Class: ClassCaller},
+	2008: {Code: 2008, Err: ErrTimestampExpired, Desc: "timestamp expired", DescZh: "时间戳已过期", Class: ClassRetryable},
+	2011: {Code: 2011, Err: ErrInsufficientBalance, Desc: "insufficient balance", DescZh: "账户余额不足", Class: ClassAccount},
+	2012: {Code: 2012, Err: ErrShopNotFound, Desc: "shop not found", DescZh: "门店不存在", Class: ClassCaller},
+	2014: {Code: 2014, Err: ErrCityNotSupported, Desc: "city not supported", DescZh: "城市未开通", Class: ClassCaller},
+	2062: {Code: 2062, Err: ErrOrderNotCancellable, Desc: "order can not be cancelled", DescZh: "订单状态不允许取消", Class: ClassCaller},
+	2076: {Code: 2076, Err: ErrOrderNotFound, Desc: "order not found", DescZh: "订单不存在", Class: ClassCaller},
+	2105: {Code: 2105, Err: ErrDuplicateOriginID, Desc: "duplicate origin_id", DescZh: "订单号重复", Class: ClassCaller},
+	2402: {Code: 2402, Err: ErrMerchantNotAvailable, Desc: "merchant not available", DescZh: "商户不可用", Class: ClassAccount},
+}
+
+// LookupCode returns the description of a return code.
+func LookupCode(code int) (*CodeInfo, bool) {
+	info, ok := codes[code]
+	return info, ok
+}
+
+// info returns the catalogue entry of the error, code is preferred over errorCode.
+func (e *APIError) info() (*CodeInfo, bool) {
+	if info, ok := LookupCode(e.Code); ok {
+		return info, true
+	}
+	return LookupCode(e.ErrorCode)
+}
+
+// Is reports whether the error matches a sentinel error of the catalogue.
+func (e *APIError) Is(target error) bool {
+	info, ok := e.info()
+	return ok && info.Err == target
+}
+
+// Class returns the classification of the error.
+func (e *APIError) Class() ErrorClass {
+	if info, ok := e.info(); ok {
+		return info.Class
+	}
+	return ClassUnknown
+}
+
+// Retryable reports whether the request may succeed when it is sent again.
+func (e *APIError) Retryable() bool {
+	return e.Class() == ClassRetryable
+}
+
+// ErrorClassOf returns the classification of err, ClassUnknown when err is neither
+// an *APIError nor a *domain.ResponseError.
+func ErrorClassOf(err error) ErrorClass {
+	if apiErr, ok := asAPIError(err); ok {
+		return apiErr.Class()
+	}
+	return ClassUnknown
+}
+
+// asAPIError finds the *APIError in err, the *domain.ResponseError of Response.Err is converted.
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	var respErr *domain.ResponseError
+	if errors.As(err, &respErr) {
+		return &APIError{Status: respErr.Status, Code: respErr.Code, ErrorCode: respErr.ErrorCode, Msg: respErr.Msg}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestResponseErrMatchesCatalogue(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      int
+		errorCode int
+		want      error
+		other     error
+		class     dadago.ErrorClass
+		retryable bool
+	}{
+		{"duplicate origin_id", 2105, 2105, dadago.ErrDuplicateOriginID, dadago.ErrOrderNotFound, dadago.ClassCaller, false},
+		{"system error", -1, -1, dadago.ErrSystem, dadago.ErrInvalidParam, dadago.ClassRetryable, true},
+		{"insufficient balance", 2011, 0, dadago.ErrInsufficientBalance, dadago.ErrShopNotFound, dadago.ClassAccount, false},
+		{"error code only", 1, 2076, dadago.ErrOrderNotFound, dadago.ErrDuplicateOriginID, dadago.ClassCaller, false},
+		{"unknown code", 9999, 9999, nil, dadago.ErrSystem, dadago.ClassUnknown, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &domain.Response[any]{Status: "fail", Code: tt.code, ErrorCode: tt.errorCode, Msg: tt.name}
+			err := fmt.Errorf("create order: %w", resp.Err())
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.want)
+			}
+			if errors.Is(err, tt.other) {
+				t.Errorf("errors.Is(%v, %v) = true", err, tt.other)
+			}
+			if class := dadago.ErrorClassOf(err); class != tt.class {
+				t.Errorf("ErrorClassOf() = %s, want %s", class, tt.class)
+			}
+			if retryable := dadago.IsRetryable(err); retryable != tt.retryable {
+				t.Errorf("IsRetryable() = %t, want %t", retryable, tt.retryable)
+			}
+			if dadago.IsGatewayFailure(err) {
+				t.Error("a return code is a gateway failure")
+			}
+		})
+	}
+	if err := (&domain.Response[any]{Status: domain.StatusSuccess}).Err(); err != nil {
+		t.Errorf("Err() of a successful response = %v", err)
+	}
+}
+
+func TestAPIErrorMatchesCatalogue(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	ctx := context.Background()
+	if _, err := c.CreateOrder(ctx, newOrder("codes-1")); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.CreateOrder(ctx, newOrder("codes-1"))
+	if !errors.Is(err, dadago.ErrDuplicateOriginID) {
+		t.Fatalf("duplicate order: %v, want ErrDuplicateOriginID", err)
+	}
+	var apiErr *dadago.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 2105 {
+		t.Errorf("duplicate order: %#v, want an *APIError with code 2105", err)
+	}
+	if class := dadago.ErrorClassOf(err); class != dadago.ClassCaller {
+		t.Errorf("ErrorClassOf() = %s, want caller", class)
+	}
+}
@@ -18,6 +18,10 @@
 
 package dadago
 
+import (
+	"github.com/houseme/imdadago/domain"
+)
+
 const (
 	// version is the default version of ImDada.
 	// 版本号，当前版本：1.0
@@ -33,6 +37,10 @@ const (
 	// See: http://newopen.imdada.cn/#/development/file/api
 	gateway = "https://newopen.imdada.cn"
 
+	// sandboxGateway is the gateway of the test environment of ImDada.
+	// See: http://newopen.imdada.cn/#/development/file/api
+	sandboxGateway = "https://newopen.qa.imdada.cn"
+
 	// userAgent is the user agent of ImDada.
 	// See: http://newopen.imdada.cn/#
 	userAgent = `Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/111.0.0.0 Safari/537.36`
@@ -87,6 +95,9 @@ const (
 	// 系统取消订单说明：超过72小时未接单系统自动取消。每天凌晨2点，取消大于72小时未完成的订单。
 	orderCancel = "/api/order/formalCancel"
 
+	// orderCancelReasons 取消原因列表
+	orderCancelReasons = "/api/order/cancel/reasons"
+
 	// additionalOrders 创建追加订单
 	additionalOrders = "/api/order/appoint/exist"
 
@@ -118,6 +129,27 @@ const (
 	fetchCodeModify = "/api/order/fetchCode/update"
 )
 
+// Simulation API of the test environment.
+const (
+	// sandboxOrderAccept 模拟接受订单
+	sandboxOrderAccept = "/api/order/accept"
+
+	// sandboxOrderFetch 模拟完成取货
+	sandboxOrderFetch = "/api/order/fetch"
+
+	// sandboxOrderFinish 模拟完成订单
+	sandboxOrderFinish = "/api/order/finish"
+
+	// sandboxOrderCancel 模拟取消订单
+	sandboxOrderCancel = "/api/order/cancel"
+
+	// sandboxOrderExpire 模拟订单过期
+	sandboxOrderExpire = "/api/order/expire"
+
+	// sandboxOrderAbnormalBack 模拟妥投异常之物品返回
+	sandboxOrderAbnormalBack = "/api/order/delivery/abnormal/back"
+)
+
 const (
 	// rechargeCateH5 H5充值
 	rechargeCateH5 = "H5"
@@ -125,35 +157,6 @@ const (
 	rechargeCatePC = "PC"
 )
 
-const (
-	foodSnacks            = 1  // 食品小吃
-	drink                 = 2  // 饮料
-	flowersAndGreenery    = 3  // 鲜花绿植
-	other                 = 5  // 其他
-	printingTicketing     = 8  // 文印票务
-	convenienceStores     = 9  // 便利店
-	freshFruit            = 13 // 水果生鲜
-	intraCityECommerce    = 19 // 同城电商
-	medicine              = 20 // 医药
-	cake                  = 21 // 蛋糕
-	wine                  = 24 // 酒品
-	smallCommodityMarkets = 25 // 小商品市场
-	clothing              = 26 // 服装
-	autoRepairParts       = 27 // 汽修零配
-	digitalAppliances     = 28 // 数码家电
-	crayfishBBQ           = 29 // 小龙虾/烧烤
-	supermarket           = 31 // 超市
-	chafingDish           = 51 // 火锅
-	personalCareMakeup    = 53 // 个护美妆
-	mother                = 55 // 母婴
-	homeTextiles          = 57 // 家居家纺
-	cellPhone             = 59 // 手机
-	home                  = 61 // 家装
-	adultProducts         = 63 // 成人用品
-	campus                = 65 // 校园
-	highEndMarket         = 66 // 高端市场
-)
-
 // RechargeCateH5 H5充值
 func RechargeCateH5() string {
 	return rechargeCateH5
@@ -165,131 +168,183 @@ func RechargeCatePC() string {
 }
 
 // FoodSnacks 食品小吃
-func FoodSnacks() int {
-	return foodSnacks
+//
+// Deprecated: use domain.BusinessFoodSnacks.
+func FoodSnacks() domain.Business {
+	return domain.BusinessFoodSnacks
 }
 
 // Drink 饮料
-func Drink() int {
-	return drink
+//
+// Deprecated: use domain.BusinessDrink.
+func Drink() domain.Business {
+	return domain.BusinessDrink
 }
 
 // FlowersAndGreenery 鲜花绿植
-func FlowersAndGreenery() int {
-	return flowersAndGreenery
+//
+// Deprecated: use domain.BusinessFlowersAndGreenery.
+func FlowersAndGreenery() domain.Business {
+	return domain.BusinessFlowersAndGreenery
 }
 
 // Other 其他
-func Other() int {
-	return other
+//
+// Deprecated: use domain.BusinessOther.
+func Other() domain.Business {
+	return domain.BusinessOther
 }
 
 // PrintingTicketing 文印票务
-func PrintingTicketing() int {
-	return printingTicketing
+//
+// Deprecated: use domain.BusinessPrintingTicketing.
+func PrintingTicketing() domain.Business {
+	return domain.BusinessPrintingTicketing
 }
 
 // ConvenienceStores 便利店
-func ConvenienceStores() int {
-	return convenienceStores
+//
+// Deprecated: use domain.BusinessConvenienceStores.
+func ConvenienceStores() domain.Business {
+	return domain.BusinessConvenienceStores
 }
 
 // FreshFruit 水果生鲜
-func FreshFruit() int {
-	return freshFruit
+//
+// Deprecated: use domain.BusinessFreshFruit.
+func FreshFruit() domain.Business {
+	return domain.BusinessFreshFruit
 }
 
 // IntraCityECommerce 同城电商
-func IntraCityECommerce() int {
-	return intraCityECommerce
+//
+// Deprecated: use domain.BusinessIntraCityECommerce.
+func IntraCityECommerce() domain.Business {
+	return domain.BusinessIntraCityECommerce
 }
 
 // Medicine 医药
-func Medicine() int {
-	return medicine
+//
+// Deprecated: use domain.BusinessMedicine.
+func Medicine() domain.Business {
+	return domain.BusinessMedicine
 }
 
 // Cake 蛋糕
-func Cake() int {
-	return cake
+//
+// Deprecated: use domain.BusinessCake.
+func Cake() domain.Business {
+	return domain.BusinessCake
 }
 
 // Wine 酒品
-func Wine() int {
-	return wine
+//
+// Deprecated: use domain.BusinessWine.
+func Wine() domain.Business {
+	return domain.BusinessWine
 }
 
 // SmallCommodityMarkets 小商品市场
-func SmallCommodityMarkets() int {
-	return smallCommodityMarkets
+//
+// Deprecated: use domain.BusinessSmallCommodityMarkets.
+func SmallCommodityMarkets() domain.Business {
+	return domain.BusinessSmallCommodityMarkets
 }
 
 // Clothing 服装
-func Clothing() int {
-	return clothing
+//
+// Deprecated: use domain.BusinessClothing.
+func Clothing() domain.Business {
+	return domain.BusinessClothing
 }
 
 // AutoRepairParts 汽修零配
-func AutoRepairParts() int {
-	return autoRepairParts
+//
+// Deprecated: use domain.BusinessAutoRepairParts.
+func AutoRepairParts() domain.Business {
+	return domain.BusinessAutoRepairParts
 }
 
 // DigitalAppliances 数码家电
-func DigitalAppliances() int {
-	return digitalAppliances
+//
+// Deprecated: use domain.BusinessDigitalAppliances.
+func DigitalAppliances() domain.Business {
+	return domain.BusinessDigitalAppliances
 }
 
 // CrayfishBBQ 小龙虾/烧烤
-func CrayfishBBQ() int {
-	return crayfishBBQ
+//
+// Deprecated: use domain.BusinessCrayfishBBQ.
+func CrayfishBBQ() domain.Business {
+	return domain.BusinessCrayfishBBQ
 }
 
 // Supermarket 超市
-func Supermarket() int {
-	return supermarket
+//
+// Deprecated: use domain.BusinessSupermarket.
+func Supermarket() domain.Business {
+	return domain.BusinessSupermarket
 }
 
 // ChafingDish 火锅
-func ChafingDish() int {
-	return chafingDish
+//
+// Deprecated: use domain.BusinessChafingDish.
+func ChafingDish() domain.Business {
+	return domain.BusinessChafingDish
 }
 
 // PersonalCareMakeup 个护美妆
-func PersonalCareMakeup() int {
-	return personalCareMakeup
+//
+// Deprecated: use domain.BusinessPersonalCareMakeup.
+func PersonalCareMakeup() domain.Business {
+	return domain.BusinessPersonalCareMakeup
 }
 
 // Mother 母婴
-func Mother() int {
-	return mother
+//
+// Deprecated: use domain.BusinessMother.
+func Mother() domain.Business {
+	return domain.BusinessMother
 }
 
 // HomeTextiles 家居家纺
-func HomeTextiles() int {
-	return homeTextiles
+//
+// Deprecated: use domain.BusinessHomeTextiles.
+func HomeTextiles() domain.Business {
+	return domain.BusinessHomeTextiles
 }
 
 // CellPhone 手机
-func CellPhone() int {
-	return cellPhone
+//
+// Deprecated: use domain.BusinessCellPhone.
+func CellPhone() domain.Business {
+	return domain.BusinessCellPhone
 }
 
 // Home 家装
-func Home() int {
-	return home
+//
+// Deprecated: use domain.BusinessHome.
+func Home() domain.Business {
+	return domain.BusinessHome
 }
 
 // AdultProducts 成人用品
-func AdultProducts() int {
-	return adultProducts
+//
+// Deprecated: use domain.BusinessAdultProducts.
+func AdultProducts() domain.Business {
+	return domain.BusinessAdultProducts
 }
 
 // Campus 校园
-func Campus() int {
-	return campus
+//
+// Deprecated: use domain.BusinessCampus.
+func Campus() domain.Business {
+	return domain.BusinessCampus
 }
 
 // HighEndMarket 高端市场
-func HighEndMarket() int {
-	return highEndMarket
+//
+// Deprecated: use domain.BusinessHighEndMarket.
+func HighEndMarket() domain.Business {
+	return domain.BusinessHighEndMarket
 }
@@ -0,0 +1,969 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+// Package dadatest provides an in-process fake of the ImDaDa gateway for offline testing.
+package dadatest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+// Return codes answered by the fake gateway, they match the catalogue of dadago.
+const (
+	CodeSuccess             = 0
+	CodeSystem              = -1
+	CodeInvalidAppKey       = 2001
+	CodeSignature           = 2003
+	CodeInvalidSourceID     = 2004
+	CodeInvalidParam        = 2006
+	CodeInsufficientBalance = 2011
+	CodeShopNotFound        = 2012
+	CodeOrderNotCancellable = 2062
+	CodeOrderNotFound       = 2076
+	CodeDuplicateOriginID   = 2105
+)
+
+// Order status of the fake gateway.
+// 待接单＝1,待取货＝2,配送中＝3,已完成＝4,已取消＝5,已过期＝7,妥投异常之物品返回中=9,妥投异常之物品返回完成=10
+const (
+	StatusWaitAccept = domain.OrderStatusWaitAccept
+	StatusWaitFetch  = domain.OrderStatusWaitFetch
+	StatusDelivering = domain.OrderStatusDelivering
+	StatusFinished   = domain.OrderStatusFinished
+	StatusCancelled  = domain.OrderStatusCancelled
+	StatusExpired    = domain.OrderStatusExpired
+	StatusReturning  = domain.OrderStatusReturning
+	StatusReturned   = domain.OrderStatusReturned
+)
+
+const (
+	transporterID      = 1001
+	transporterName    = "dadatest"
+	transporterPhone   = "13800000000"
+	transporterLat     = "31.230416"
+	transporterLng     = "121.473701"
+	defaultDeliverFee  = domain.Money(1000)
+	defaultDistance    = 1000
+	deliveryNoLifetime = 180
+)
+
+// options is the configuration of Server.
+type options struct {
+	AppKey     string
+	AppSecret  string
+	SourceID   string
+	Balance    domain.Money
+	DeliverFee domain.Money
+}
+
+// Option the option is a Server option.
+type Option func(o *options)
+
+// WithAppKey sets the app key accepted by the server.
+func WithAppKey(appKey string) Option {
+	return func(o *options) {
+		o.AppKey = appKey
+	}
+}
+
+// WithAppSecret sets the app secret used to verify the signatures.
+func WithAppSecret(appSecret string) Option {
+	return func(o *options) {
+		o.AppSecret = appSecret
+	}
+}
+
+// WithSourceID sets the source id accepted by the server.
+func WithSourceID(sourceID string) Option {
+	return func(o *options) {
+		o.SourceID = sourceID
+	}
+}
+
+// WithBalance sets the initial deliver balance of the merchant.
+func WithBalance(balance domain.Money) Option {
+	return func(o *options) {
+		o.Balance = balance
+	}
+}
+
+// WithDeliverFee sets the deliver fee of every order.
+func WithDeliverFee(fee domain.Money) Option {
+	return func(o *options) {
+		o.DeliverFee = fee
+	}
+}
+
+// Fault is a failure injected into the answers of an API.
+type Fault struct {
+	Code       int           // 返回码，非0时返回失败响应
+	Msg        string        // 失败描述
+	HTTPStatus int           // 非0时直接返回该 http 状态码
+	Latency    time.Duration // 响应前的延迟
+	Times      int           // 生效次数，0表示一直生效
+}
+
+// Order is an order kept by the server.
+type Order struct {
+	ClientID    string             // 达达运单号
+	Status      domain.OrderStatus // 订单状态
+	Fee         domain.Money
+	Tips        domain.Money
+	UpdateTime  int64
+	Request     domain.OrdersCreateRequest
+	CancelFrom  int
+	CancelMsg   string
+	DeductFee   domain.Money
+	FinishCode  string
+	Transporter bool
+}
+
+// Callback is an order status callback sent by the server.
+type Callback struct {
+	URL        string
+	Notify     domain.OrdersAsyncResponse
+	StatusCode int
+	Err        error
+}
+
+// Server is a fake of the ImDaDa gateway listening on a local address.
+// It verifies app_key and signature, keeps shops, orders and balances in memory
+// and sends signed order status callbacks to the callback url of the orders.
+type Server struct {
+	URL string
+
+	op     options
+	server *httptest.Server
+	client *http.Client
+
+	// ctx is the context of the callbacks, it outlives the API requests and ends in Close.
+	ctx  context.Context
+	stop context.CancelFunc
+
+	mu         sync.Mutex
+	sending    int        // 发送中的回调批次
+	idle       *sync.Cond // sending 归零时广播
+	shops      map[string]*domain.ShopQueryItem
+	orders     map[string]*Order
+	deliveries map[string]*domain.DeliverFeeQueryRequest
+	faults     map[string]*Fault
+	callbacks  []*Callback
+	confirms   []domain.MessageBodyConfirm
+	balance    domain.Money
+	sequence   int64
+}
+
+// NewServer starts a Server, the caller must Close it.
+func NewServer(opts ...Option) *Server {
+	op := options{
+		AppKey:     "dadatest-app-key",
+		AppSecret:  "dadatest-app-secret",
+		SourceID:   "73753",
+		Balance:    domain.Money(100000),
+		DeliverFee: defaultDeliverFee,
+	}
+	for _, option := range opts {
+		option(&op)
+	}
+	s := &Server{
+		op:         op,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		shops:      make(map[string]*domain.ShopQueryItem),
+		orders:     make(map[string]*Order),
+		deliveries: make(map[string]*domain.DeliverFeeQueryRequest),
+		faults:     make(map[string]*Fault),
+		balance:    op.Balance,
+	}
+	s.idle = sync.NewCond(&s.mu)
+	s.ctx, s.stop = context.WithCancel(context.Background())
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server after the callbacks in flight are sent.
+func (s *Server) Close() {
+	s.server.Close()
+	s.WaitCallbacks()
+	s.stop()
+}
+
+// WaitCallbacks waits for the callbacks in flight, call it before Callbacks to see the callbacks of the last API call.
+// The callbacks are counted under the lock of the handlers, an API call returned before is always waited for.
+func (s *Server) WaitCallbacks() {
+	s.mu.Lock()
+	for s.sending > 0 {
+		s.idle.Wait()
+	}
+	s.mu.Unlock()
+}
+
+// ClientOptions returns the options of a dadago.Client talking to the server.
+func (s *Server) ClientOptions() []dadago.Option {
+	return []dadago.Option{
+		dadago.WithGateway(s.URL),
+		dadago.WithAppKey(s.op.AppKey),
+		dadago.WithAppSecret(s.op.AppSecret),
+		dadago.WithSourceID(s.op.SourceID),
+	}
+}
+
+// AddShop adds a shop, orders can only be created for known shops.
+func (s *Server) AddShop(shop domain.ShopQueryItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shops[shop.OriginShopID] = &shop
+}
+
+// SetBalance sets the deliver balance of the merchant.
+func (s *Server) SetBalance(balance domain.Money) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balance = balance
+}
+
+// Balance returns the deliver balance of the merchant.
+func (s *Server) Balance() domain.Money {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balance
+}
+
+// Order returns a copy of the order of originID.
+func (s *Server) Order(originID string) (Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[originID]
+	if !ok {
+		return Order{}, false
+	}
+	return *o, true
+}
+
+// Confirmations returns the answers to the rider cancellation requests received by the server.
+func (s *Server) Confirmations() []domain.MessageBodyConfirm {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.MessageBodyConfirm(nil), s.confirms...)
+}
+
+// Callbacks returns the callbacks sent so far, they are sent asynchronously, see WaitCallbacks.
+func (s *Server) Callbacks() []Callback {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	callbacks := make([]Callback, 0, len(s.callbacks))
+	for _, cb := range s.callbacks {
+		callbacks = append(callbacks, *cb)
+	}
+	return callbacks
+}
+
+// InjectFault makes the API at path answer with the fault, it replaces a previous fault of path.
+func (s *Server) InjectFault(path string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[path] = &fault
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]*Fault)
+}
+
+// result is the outcome of a handler.
+type result struct {
+	code      int
+	msg       string
+	data      any
+	callbacks []*Callback
+}
+
+func ok(data any) *result {
+	return &result{code: CodeSuccess, msg: "成功", data: data}
+}
+
+func fail(code int, msg string) *result {
+	return &result{code: code, msg: msg}
+}
+
+// handler handles the business parameters of an API, it is called with the lock held.
+type handler func(s *Server, body string) *result
+
+// routes are the APIs implemented by the server.
+var routes = map[string]handler{
+	"/api/balance/query":                  (*Server).queryBalance,
+	"/api/recharge":                       (*Server).recharge,
+	"/api/cityCode/list":                  (*Server).cityList,
+	"/merchantApi/merchant/add":           (*Server).merchantAdd,
+	"/api/shop/add":                       (*Server).shopAdd,
+	"/api/shop/update":                    (*Server).shopUpdate,
+	"/api/shop/detail":                    (*Server).shopDetail,
+	"/api/order/addOrder":                 (*Server).addOrder,
+	"/api/order/reAddOrder":               (*Server).reAddOrder,
+	"/api/order/queryDeliverFee":          (*Server).queryDeliverFee,
+	"/api/order/status/addAfterQuery":     (*Server).addAfterQuery,
+	"/api/order/addTip":                   (*Server).addTip,
+	"/api/order/status/query":             (*Server).statusQuery,
+	"/api/order/formalCancel":             (*Server).formalCancel,
+	"/api/order/confirm/goods":            (*Server).confirmGoods,
+	"/api/order/transporter/position":     (*Server).transporterPosition,
+	"/api/order/transporter/track":        (*Server).transporterTrack,
+	"/api/complaint/reasons":              (*Server).complaintReasons,
+	"/api/order/cancel/reasons":           (*Server).cancelReasons,
+	"/api/order/accept":                   simulate(StatusWaitFetch, StatusWaitAccept),
+	"/api/order/fetch":                    simulate(StatusDelivering, StatusWaitFetch),
+	"/api/order/finish":                   simulate(StatusFinished, StatusDelivering),
+	"/api/order/expire":                   simulate(StatusExpired, StatusWaitAccept),
+	"/api/order/delivery/abnormal/back":   simulate(StatusReturning, StatusDelivering),
+	"/api/order/cancel":                   (*Server).simulateCancel,
+	"/api/message/confirm":                (*Server).confirmMessage,
+	"/api/complaint/dada":                 (*Server).acknowledge,
+	"/api/order/fetchCode/update":         (*Server).acknowledge,
+	"/api/order/appoint/exist":            (*Server).acknowledge,
+	"/api/order/appoint/cancel":           (*Server).acknowledge,
+	"/api/order/appoint/list/transporter": (*Server).appointList,
+}
+
+// serveHTTP verifies the envelope, applies the injected faults and dispatches to the handler of the path.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if fault := s.takeFault(path); fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.HTTPStatus != 0 {
+			http.Error(w, http.StatusText(fault.HTTPStatus), fault.HTTPStatus)
+			return
+		}
+		if fault.Code != 0 {
+			s.write(w, fail(fault.Code, fault.Msg))
+			return
+		}
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var envelope domain.Request
+	if err = sonic.Unmarshal(data, &envelope); err != nil {
+		s.write(w, fail(CodeInvalidParam, "请求格式错误"))
+		return
+	}
+	if res := s.verify(path, &envelope); res != nil {
+		s.write(w, res)
+		return
+	}
+	h, found := routes[path]
+	if !found {
+		s.write(w, fail(CodeInvalidParam, "接口不存在"))
+		return
+	}
+
+	s.mu.Lock()
+	res := h(s, envelope.Body)
+	if len(res.callbacks) > 0 {
+		s.sending++
+	}
+	s.mu.Unlock()
+	s.write(w, res)
+	if len(res.callbacks) == 0 {
+		return
+	}
+	// Like the real gateway, the callbacks follow the response and do not depend on the API request.
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	go func(callbacks []*Callback) {
+		defer s.sent()
+		for _, cb := range callbacks {
+			s.send(s.ctx, cb)
+		}
+	}(res.callbacks)
+}
+
+// sent ends a batch of callbacks and wakes up WaitCallbacks after the last one.
+func (s *Server) sent() {
+	s.mu.Lock()
+	if s.sending--; s.sending == 0 {
+		s.idle.Broadcast()
+	}
+	s.mu.Unlock()
+}
+
+// takeFault returns the fault of path and counts its use.
+func (s *Server) takeFault(path string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fault, found := s.faults[path]
+	if !found {
+		return nil
+	}
+	if fault.Times > 0 {
+		if fault.Times--; fault.Times == 0 {
+			delete(s.faults, path)
+		}
+	}
+	f := *fault
+	return &f
+}
+
+// verify checks the app_key, source_id and signature of the envelope.
+func (s *Server) verify(path string, envelope *domain.Request) *result {
+	if envelope.AppKey != s.op.AppKey {
+		return fail(CodeInvalidAppKey, "app_key无效")
+	}
+	if envelope.SourceID != s.op.SourceID && path != "/merchantApi/merchant/add" {
+		return fail(CodeInvalidSourceID, "source_id不合法")
+	}
+	if dadago.Sign(s.op.AppSecret, envelope) != envelope.Signature {
+		return fail(CodeSignature, "签名错误")
+	}
+	return nil
+}
+
+// write writes the response envelope.
+func (s *Server) write(w http.ResponseWriter, res *result) {
+	resp := domain.Response[any]{
+		Status:    domain.StatusSuccess,
+		Result:    res.data,
+		Code:      res.code,
+		Msg:       res.msg,
+		Success:   true,
+		ErrorCode: res.code,
+	}
+	if res.code != CodeSuccess {
+		resp.Status, resp.Success, resp.Fail = "fail", false, true
+	}
+	data, err := sonic.Marshal(&resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// send posts a signed callback and records the outcome.
+func (s *Server) send(ctx context.Context, cb *Callback) {
+	data, err := sonic.Marshal(&cb.Notify)
+	if err == nil {
+		var req *http.Request
+		if req, err = http.NewRequestWithContext(ctx, http.MethodPost, cb.URL, bytes.NewReader(data)); err == nil {
+			req.Header.Set("Content-Type", "application/json")
+			var resp *http.Response
+			if resp, err = s.client.Do(req); err == nil {
+				cb.StatusCode = resp.StatusCode
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+		}
+	}
+	s.mu.Lock()
+	cb.Err = err
+	s.callbacks = append(s.callbacks, cb)
+	s.mu.Unlock()
+}
+
+// decode decodes the business parameters.
+func decode(body string, v any) *result {
+	if err := sonic.UnmarshalString(body, v); err != nil {
+		return fail(CodeInvalidParam, "参数格式错误: "+err.Error())
+	}
+	return nil
+}
+
+// transition changes the status of the order and returns the callback of the change.
+func (s *Server) transition(o *Order, status domain.OrderStatus) *Callback {
+	o.Status = status
+	o.UpdateTime = time.Now().Unix()
+	if status == StatusWaitFetch {
+		o.Transporter = true
+	}
+	if o.Request.Callback == "" {
+		return nil
+	}
+	notify := domain.OrdersAsyncResponse{
+		ClientID:     o.ClientID,
+		OrderID:      o.Request.OriginID,
+		OrderStatus:  status,
+		CancelReason: o.CancelMsg,
+		CancelFrom:   o.CancelFrom,
+		UpdateTime:   o.UpdateTime,
+		FinishCode:   o.FinishCode,
+	}
+	if o.Transporter {
+		notify.DmID, notify.DmName, notify.DmMobile = transporterID, transporterName, transporterPhone
+	}
+	notify.Signature = dadago.CallbackSignature(notify.UpdateTime, notify.ClientID, notify.OrderID)
+	return &Callback{URL: o.Request.Callback, Notify: notify}
+}
+
+// withCallback attaches the callback to the result.
+func withCallback(res *result, cb *Callback) *result {
+	if cb != nil {
+		res.callbacks = append(res.callbacks, cb)
+	}
+	return res
+}
+
+func (s *Server) queryBalance(_ string) *result {
+	return ok(&domain.BalanceResult{DeliverBalance: s.balance})
+}
+
+func (s *Server) recharge(body string) *result {
+	var req domain.RechargeRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if req.Amount <= 0 {
+		return fail(CodeInvalidParam, "充值金额不合法")
+	}
+	s.balance += req.Amount
+	return ok(s.URL + "/recharge?amount=" + req.Amount.String())
+}
+
+func (s *Server) cityList(_ string) *result {
+	return ok([]*domain.CityItem{
+		{CityName: "上海", CityCode: "021"},
+		{CityName: "北京", CityCode: "010"},
+	})
+}
+
+func (s *Server) merchantAdd(body string) *result {
+	var req domain.MerchantCreateRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	s.sequence++
+	return ok(s.sequence)
+}
+
+func (s *Server) shopAdd(body string) *result {
+	var req domain.ShopCreateRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	out := &domain.ShopCreateResult{}
+	for _, item := range req {
+		if _, found := s.shops[item.OriginShopID]; found || item.OriginShopID == "" {
+			out.FailedList = append(out.FailedList, &domain.ShopCreateFailedItem{
+				ShopNo: item.OriginShopID, ShopName: item.StationName, Msg: "门店编号已存在",
+			})
+			continue
+		}
+		if !item.Business.Valid() {
+			out.FailedList = append(out.FailedList, &domain.ShopCreateFailedItem{
+				ShopNo: item.OriginShopID, ShopName: item.StationName, Msg: "不支持的业务类型",
+			})
+			continue
+		}
+		s.shops[item.OriginShopID] = &domain.ShopQueryItem{
+			StationName:    item.StationName,
+			StationAddress: item.StationAddress,
+			ContactName:    item.ContactName,
+			OriginShopID:   item.OriginShopID,
+			Business:       item.Business,
+			Lng:            item.Lng,
+			Lat:            item.Lat,
+			Phone:          item.Phone,
+			Status:         1,
+		}
+		out.Success++
+		out.SuccessList = append(out.SuccessList, &domain.ShopCreateSuccessItem{
+			Phone:          item.Phone,
+			Business:       item.Business,
+			Lng:            item.Lng,
+			Lat:            item.Lat,
+			StationName:    item.StationName,
+			OriginShopID:   item.OriginShopID,
+			ContactName:    item.ContactName,
+			StationAddress: item.StationAddress,
+		})
+	}
+	return ok(out)
+}
+
+func (s *Server) shopUpdate(body string) *result {
+	var req domain.ShopUpdateRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	shop, found := s.shops[req.OriginShopID]
+	if !found {
+		return fail(CodeShopNotFound, "门店不存在")
+	}
+	if req.StationName != "" {
+		shop.StationName = req.StationName
+	}
+	if req.StationAddress != "" {
+		shop.StationAddress = req.StationAddress
+	}
+	if req.ContactName != "" {
+		shop.ContactName = req.ContactName
+	}
+	if req.Phone != "" {
+		shop.Phone = req.Phone
+	}
+	if err := req.Validate(); err != nil {
+		return fail(CodeInvalidParam, "不支持的业务类型")
+	}
+	if req.Business != 0 {
+		shop.Business = req.Business
+	}
+	if req.Lat != 0 || req.Lng != 0 {
+		shop.Lat, shop.Lng = req.Lat, req.Lng
+	}
+	return ok(nil)
+}
+
+func (s *Server) shopDetail(body string) *result {
+	var req domain.ShopQueryRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	shop, found := s.shops[req.OriginShopID]
+	if !found {
+		return fail(CodeShopNotFound, "门店不存在")
+	}
+	return ok(shop)
+}
+
+// create creates an order and charges the deliver fee.
+func (s *Server) create(req *domain.OrdersCreateRequest, replace bool) *result {
+	if _, found := s.shops[req.ShopNo]; !found {
+		return fail(CodeShopNotFound, "门店不存在")
+	}
+	if req.OriginID == "" {
+		return fail(CodeInvalidParam, "origin_id不能为空")
+	}
+	if o, found := s.orders[req.OriginID]; found {
+		if !replace {
+			return fail(CodeDuplicateOriginID, "订单号重复")
+		}
+		if o.Status != StatusCancelled && o.Status != StatusExpired {
+			return fail(CodeInvalidParam, "订单状态不允许重新发布")
+		}
+	}
+	fee := s.op.DeliverFee + req.Tips
+	if s.balance < fee {
+		return fail(CodeInsufficientBalance, "账户余额不足")
+	}
+	s.balance -= fee
+	s.sequence++
+	o := &Order{
+		ClientID: strconv.FormatInt(s.sequence, 10),
+		Fee:      fee,
+		Tips:     req.Tips,
+		Request:  *req,
+	}
+	if req.IsFinishCodeNeeded == 1 {
+		o.FinishCode = "1234"
+	}
+	s.orders[req.OriginID] = o
+	return withCallback(ok(&domain.OrdersCreateResult{
+		Distance:   defaultDistance,
+		Fee:        fee,
+		DeliverFee: s.op.DeliverFee,
+		Tips:       req.Tips,
+	}), s.transition(o, StatusWaitAccept))
+}
+
+func (s *Server) addOrder(body string) *result {
+	var req domain.OrdersCreateRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	return s.create(&req, false)
+}
+
+func (s *Server) reAddOrder(body string) *result {
+	var req domain.OrdersCreateRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	return s.create(&req, true)
+}
+
+func (s *Server) queryDeliverFee(body string) *result {
+	var req domain.DeliverFeeQueryRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if _, found := s.shops[req.ShopNo]; !found {
+		return fail(CodeShopNotFound, "门店不存在")
+	}
+	s.sequence++
+	deliveryNo := "dadatest-" + strconv.FormatInt(s.sequence, 10)
+	s.deliveries[deliveryNo] = &req
+	return ok(&domain.DeliverFeeQueryResult{
+		Distance:    defaultDistance,
+		Fee:         s.op.DeliverFee + req.Tips,
+		DeliverFee:  s.op.DeliverFee,
+		DeliveryNo:  deliveryNo,
+		Tips:        req.Tips,
+		ExpiredTime: int(time.Now().Unix()) + deliveryNoLifetime,
+	})
+}
+
+func (s *Server) addAfterQuery(body string) *result {
+	var req domain.OrdersCreateByDeliverFeeQueryRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	query, found := s.deliveries[req.DeliveryNo]
+	if !found {
+		return fail(CodeInvalidParam, "deliveryNo不存在或已过期")
+	}
+	delete(s.deliveries, req.DeliveryNo)
+	create := domain.OrdersCreateRequest(*query)
+	return s.create(&create, false)
+}
+
+func (s *Server) addTip(body string) *result {
+	var req domain.OrdersAddTipRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	o, found := s.orders[req.OrderID]
+	if !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	if o.Status != StatusWaitAccept {
+		return fail(CodeInvalidParam, "订单状态不允许加小费")
+	}
+	if s.balance < req.Tips {
+		return fail(CodeInsufficientBalance, "账户余额不足")
+	}
+	s.balance -= req.Tips
+	o.Tips += req.Tips
+	o.Fee += req.Tips
+	return ok(nil)
+}
+
+func (s *Server) statusQuery(body string) *result {
+	var req domain.OrdersQueryRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	o, found := s.orders[req.OrderID]
+	if !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	out := &domain.OrdersQueryResult{
+		OrderID:         req.OrderID,
+		StatusCode:      o.Status,
+		DeliveryFee:     o.Fee - o.Tips,
+		Tips:            o.Tips,
+		Distance:        defaultDistance,
+		ActualFee:       o.Fee,
+		OrderFinishCode: o.FinishCode,
+		DeductFee:       o.DeductFee,
+	}
+	if o.Transporter {
+		out.TransporterID = transporterID
+		out.TransporterName = transporterName
+		out.TransporterPhone = transporterPhone
+		out.TransporterLat = transporterLat
+		out.TransporterLng = transporterLng
+	}
+	return ok(out)
+}
+
+// cancel cancels an order and refunds the fee.
+func (s *Server) cancel(o *Order, from int, reason string) *Callback {
+	s.balance += o.Fee
+	o.CancelFrom, o.CancelMsg = from, reason
+	return s.transition(o, StatusCancelled)
+}
+
+func (s *Server) formalCancel(body string) *result {
+	var req domain.OrdersCancelRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if err := req.Validate(); err != nil {
+		return fail(CodeInvalidParam, err.Error())
+	}
+	o, found := s.orders[req.OrderID]
+	if !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	if o.Status != StatusWaitAccept && o.Status != StatusWaitFetch {
+		return fail(CodeOrderNotCancellable, "订单状态不允许取消")
+	}
+	reason := req.CancelReason
+	if reason == "" {
+		reason = req.CancelReasonID.String()
+	}
+	cb := s.cancel(o, 2, reason)
+	return withCallback(ok(&domain.OrdersCancelResult{DeductFee: o.DeductFee}), cb)
+}
+
+func (s *Server) confirmGoods(body string) *result {
+	var req domain.OrdersConfirmGoodsRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	o, found := s.orders[req.OrderID]
+	if !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	if o.Status != StatusReturning {
+		return fail(CodeInvalidParam, "订单状态不允许确认物品返还")
+	}
+	return withCallback(ok(nil), s.transition(o, StatusReturned))
+}
+
+func (s *Server) transporterPosition(body string) *result {
+	var req domain.OrdersTransporterPositionRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if len(req.OrderIDS) > 50 {
+		return fail(CodeInvalidParam, "订单号最多50个")
+	}
+	out := make([]*domain.OuterPositionInfo, 0, len(req.OrderIDS))
+	for _, id := range req.OrderIDS {
+		if o, found := s.orders[id]; found && o.Transporter {
+			out = append(out, &domain.OuterPositionInfo{
+				OrderID:          id,
+				TransporterLat:   transporterLat,
+				TransporterLng:   transporterLng,
+				TransporterName:  transporterName,
+				TransporterPhone: transporterPhone,
+			})
+		}
+	}
+	return ok(out)
+}
+
+func (s *Server) transporterTrack(body string) *result {
+	var req domain.OrdersTransporterTrackRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if _, found := s.orders[req.OrderID]; !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	return ok(&domain.OrdersTransporterTrackResult{TrackURL: s.URL + "/track?order_id=" + req.OrderID})
+}
+
+func (s *Server) complaintReasons(_ string) *result {
+	return ok([]*domain.ComplaintReasonResult{
+		{ID: 1, Reason: "达达配送员态度恶劣"},
+		{ID: 2, Reason: "达达配送员未按时取货"},
+	})
+}
+
+func (s *Server) cancelReasons(_ string) *result {
+	reasons := []domain.CancelReason{
+		domain.CancelReasonNoTransporter, domain.CancelReasonNoPickup, domain.CancelReasonBadAttitude,
+		domain.CancelReasonCustomerCancelled, domain.CancelReasonWrongOrder, domain.CancelReasonTransporterAsked,
+		domain.CancelReasonTransporterNoPickup, domain.CancelReasonNotNeeded, domain.CancelReasonTransporterCantServe,
+		domain.CancelReasonOther,
+	}
+	out := make([]*domain.CancelReasonItem, 0, len(reasons))
+	for _, r := range reasons {
+		out = append(out, &domain.CancelReasonItem{ID: r, Reason: r.String()})
+	}
+	return ok(out)
+}
+
+func (s *Server) appointList(_ string) *result {
+	return ok([]*domain.OrdersTransporterItem{{ID: transporterID, Name: transporterName, CityID: 1}})
+}
+
+// confirmMessage records the answer to a rider cancellation request, the message body must be a JSON string.
+func (s *Server) confirmMessage(body string) *result {
+	var raw struct {
+		MessageBody sonic.NoCopyRawMessage `json:"messageBody"`
+	}
+	if res := decode(body, &raw); res != nil {
+		return res
+	}
+	if len(raw.MessageBody) == 0 || raw.MessageBody[0] != '"' {
+		return fail(CodeInvalidParam, "messageBody 必须为json字符串")
+	}
+	var req domain.OrdersTransporterCancelAsyncConfirmRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	if req.MessageBody.OrderID == "" {
+		return fail(CodeInvalidParam, "orderId 不能为空")
+	}
+	s.confirms = append(s.confirms, req.MessageBody)
+	return ok(nil)
+}
+
+func (s *Server) acknowledge(_ string) *result {
+	return ok(nil)
+}
+
+// simulate returns the handler of a simulation API moving an order from the status from to the status to.
+func simulate(to, from domain.OrderStatus) handler {
+	return func(s *Server, body string) *result {
+		var req domain.SandboxOrderRequest
+		if res := decode(body, &req); res != nil {
+			return res
+		}
+		o, found := s.orders[req.OrderID]
+		if !found {
+			return fail(CodeOrderNotFound, "订单不存在")
+		}
+		if o.Status != from {
+			return fail(CodeInvalidParam, "订单状态不允许该操作")
+		}
+		return withCallback(ok(nil), s.transition(o, to))
+	}
+}
+
+func (s *Server) simulateCancel(body string) *result {
+	var req domain.SandboxOrderCancelRequest
+	if res := decode(body, &req); res != nil {
+		return res
+	}
+	o, found := s.orders[req.OrderID]
+	if !found {
+		return fail(CodeOrderNotFound, "订单不存在")
+	}
+	switch o.Status {
+	case StatusFinished, StatusCancelled, StatusExpired, StatusReturned:
+		return fail(CodeOrderNotCancellable, "订单状态不允许取消")
+	}
+	return withCallback(ok(nil), s.cancel(o, 1, req.Reason))
+}
@@ -0,0 +1,287 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadatest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const shopNo = "dadatest-shop"
+
+// newClient returns a client of the server, opts override the options of the server.
+func newClient(t *testing.T, s *dadatest.Server, opts ...dadago.Option) *dadago.Client {
+	t.Helper()
+	opts = append(append(s.ClientOptions(),
+		dadago.WithLogPath(t.TempDir()),
+		dadago.WithLevel(dadago.Level(hlog.LevelError)),
+	), opts...)
+	c := dadago.New(context.Background(), opts...)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func newServer(t *testing.T, opts ...dadatest.Option) *dadatest.Server {
+	t.Helper()
+	s := dadatest.NewServer(opts...)
+	s.AddShop(domain.ShopQueryItem{OriginShopID: shopNo})
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newOrder(originID, callback string) *domain.OrdersCreateRequest {
+	return &domain.OrdersCreateRequest{
+		ShopNo:        shopNo,
+		OriginID:      originID,
+		CargoPrice:    domain.Yuan(30),
+		ReceiverLat:   31.23,
+		ReceiverLng:   121.47,
+		ReceiverPhone: "13800000000",
+		Callback:      callback,
+	}
+}
+
+func TestSignatureRejected(t *testing.T) {
+	s := newServer(t)
+	tests := []struct {
+		name string
+		opt  dadago.Option
+		want error
+	}{
+		{name: "secret", opt: dadago.WithAppSecret("wrong-secret"), want: dadago.ErrSignature},
+		{name: "app key", opt: dadago.WithAppKey("wrong-key"), want: dadago.ErrInvalidAppKey},
+		{name: "source id", opt: dadago.WithSourceID("0"), want: dadago.ErrInvalidSourceID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newClient(t, s, tt.opt).QueryBalance(context.Background(), &domain.QueryBalanceRequest{})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if _, err := newClient(t, s).QueryBalance(context.Background(), &domain.QueryBalanceRequest{}); err != nil {
+		t.Errorf("signed request rejected: %v", err)
+	}
+}
+
+func TestInjectFault(t *testing.T) {
+	s := newServer(t)
+	c := newClient(t, s)
+	ctx := context.Background()
+	query := func() error {
+		_, err := c.QueryBalance(ctx, &domain.QueryBalanceRequest{})
+		return err
+	}
+
+	s.InjectFault("/api/balance/query", dadatest.Fault{Code: dadatest.CodeInvalidParam, Msg: "参数错误", Times: 2})
+	for i := 0; i < 2; i++ {
+		var apiErr *dadago.APIError
+		if err := query(); !errors.As(err, &apiErr) || apiErr.Code != dadatest.CodeInvalidParam {
+			t.Fatalf("call %d: error %v, want code %d", i, err, dadatest.CodeInvalidParam)
+		}
+	}
+	if err := query(); err != nil {
+		t.Fatalf("fault applied more than Times: %v", err)
+	}
+
+	s.InjectFault("/api/balance/query", dadatest.Fault{HTTPStatus: http.StatusServiceUnavailable, Times: 1})
+	var httpErr *dadago.HTTPError
+	if err := query(); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("error %v, want http status 503", err)
+	}
+
+	s.InjectFault("/api/balance/query", dadatest.Fault{Latency: 100 * time.Millisecond, Times: 1})
+	start := time.Now()
+	if err := query(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("latency %v, want at least 100ms", elapsed)
+	}
+
+	s.InjectFault("/api/balance/query", dadatest.Fault{Code: dadatest.CodeSystem, Msg: "系统错误"})
+	for i := 0; i < 3; i++ {
+		if err := query(); err == nil {
+			t.Fatal("fault with zero Times stopped applying")
+		}
+	}
+	s.ClearFaults()
+	if err := query(); err != nil {
+		t.Fatalf("fault not cleared: %v", err)
+	}
+}
+
+// receiver starts a callback endpoint passing the callbacks to notify.
+func receiver(t *testing.T, c *dadago.Client, notify func(*domain.OrdersAsyncResponse)) string {
+	t.Helper()
+	r := c.NewCallbackReceiver()
+	h := func(_ context.Context, n *domain.OrdersAsyncResponse) error {
+		notify(n)
+		return nil
+	}
+	r.OnWaitAccept, r.OnAccepted, r.OnFetched, r.OnFinished, r.OnCancelled, r.OnOther = h, h, h, h, h, h
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestOrderTransitions(t *testing.T) {
+	s := newServer(t)
+	c := newClient(t, s)
+	ctx := context.Background()
+	statuses := make(chan domain.OrderStatus, 16)
+	url := receiver(t, c, func(n *domain.OrdersAsyncResponse) { statuses <- n.OrderStatus })
+
+	before := s.Balance()
+	resp, err := c.CreateOrder(ctx, newOrder("life-1", url))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := before - s.Balance(); got != resp.Result.Fee {
+		t.Errorf("charged %v, want the fee %v", got, resp.Result.Fee)
+	}
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &domain.SandboxOrderRequest{OrderID: "life-1"}
+	if _, err = sandbox.FinishOrder(ctx, req); err == nil {
+		t.Error("finished an order waiting for acceptance")
+	}
+	for _, step := range []func(context.Context, *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error){
+		sandbox.AcceptOrder, sandbox.FetchOrder, sandbox.FinishOrder,
+	} {
+		// Each request sends its own callbacks, wait for them to keep the order.
+		s.WaitCallbacks()
+		if _, err = step(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.WaitCallbacks()
+	want := []domain.OrderStatus{domain.OrderStatusWaitAccept, domain.OrderStatusWaitFetch, domain.OrderStatusDelivering, domain.OrderStatusFinished}
+	for _, status := range want {
+		if got := <-statuses; got != status {
+			t.Fatalf("callback status %v, want %v", got, status)
+		}
+	}
+	if o, _ := s.Order("life-1"); o.Status != domain.OrderStatusFinished {
+		t.Errorf("order status %v, want finished", o.Status)
+	}
+	for _, cb := range s.Callbacks() {
+		if cb.Err != nil || cb.StatusCode != http.StatusOK {
+			t.Errorf("callback %v answered %d: %v", cb.Notify.OrderStatus, cb.StatusCode, cb.Err)
+		}
+	}
+	if _, err = c.CancelOrder(ctx, &domain.OrdersCancelRequest{OrderID: "life-1", CancelReasonID: domain.CancelReasonNotNeeded}); !errors.Is(err, dadago.ErrOrderNotCancellable) {
+		t.Errorf("cancel of a finished order: %v, want ErrOrderNotCancellable", err)
+	}
+
+	if _, err = c.CreateOrder(ctx, newOrder("life-2", "")); err != nil {
+		t.Fatal(err)
+	}
+	before = s.Balance()
+	if _, err = c.CancelOrder(ctx, &domain.OrdersCancelRequest{OrderID: "life-2", CancelReasonID: domain.CancelReasonNotNeeded}); err != nil {
+		t.Fatal(err)
+	}
+	if o, _ := s.Order("life-2"); o.Status != domain.OrderStatusCancelled || s.Balance() <= before {
+		t.Errorf("cancelled order status %v, balance %v -> %v, want cancelled and refunded", o.Status, before, s.Balance())
+	}
+}
+
+func TestCallbackFollowsResponse(t *testing.T) {
+	s := newServer(t)
+	c := newClient(t, s)
+	created := make(chan struct{})
+	received := make(chan bool, 1)
+	url := receiver(t, c, func(*domain.OrdersAsyncResponse) {
+		select {
+		case <-created:
+			received <- true
+		case <-time.After(2 * time.Second):
+			received <- false
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := c.CreateOrder(ctx, newOrder("follow-1", url)); err != nil {
+		t.Fatal(err)
+	}
+	close(created)
+	// The callback belongs to the server, not to the API request.
+	cancel()
+	if !<-received {
+		t.Fatal("the callback was sent before CreateOrder returned")
+	}
+	s.WaitCallbacks()
+	if cbs := s.Callbacks(); len(cbs) != 1 || cbs[0].Err != nil {
+		t.Errorf("callbacks %+v, want one delivered callback", cbs)
+	}
+}
+
+// TestWaitCallbacksConcurrent waits for the callbacks while API calls start new ones, run it with -race.
+func TestWaitCallbacksConcurrent(t *testing.T) {
+	s := newServer(t, dadatest.WithBalance(domain.Yuan(100000)))
+	c := newClient(t, s)
+	url := receiver(t, c, func(*domain.OrdersAsyncResponse) {})
+
+	const orders = 20
+	var wg sync.WaitGroup
+	for i := 0; i < orders; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			originID := fmt.Sprintf("wait-%d", i)
+			if _, err := c.CreateOrder(context.Background(), newOrder(originID, url)); err != nil {
+				t.Error(err)
+				return
+			}
+			// The callback of a returned call is always waited for.
+			s.WaitCallbacks()
+			found := false
+			for _, cb := range s.Callbacks() {
+				found = found || cb.Notify.OrderID == originID
+			}
+			if !found {
+				t.Errorf("WaitCallbacks() returned before the callback of %s", originID)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			s.WaitCallbacks()
+		}()
+	}
+	wg.Wait()
+	s.WaitCallbacks()
+	if n := len(s.Callbacks()); n != orders {
+		t.Errorf("%d callbacks, want %d", n, orders)
+	}
+}
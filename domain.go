@@ -20,12 +20,12 @@
 package dadago
 
 import (
+	"crypto/tls"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/network"
 	"github.com/cloudwego/hertz/pkg/protocol"
-
-	"github.com/houseme/imdadago/domain"
 )
 
 // Level is the log level.
@@ -47,6 +47,32 @@ type options struct {
 	TimeOut   time.Duration
 	UserAgent []byte
 	Debug     bool
+
+	MaxConnsPerHost int           // 单个 host 最大连接数
+	IdleTimeout     time.Duration // 空闲连接保持时间
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	Dialer          network.Dialer
+	Proxy           protocol.Proxy
+
+	TLSConfig          *tls.Config
+	InsecureSkipVerify bool // 跳过证书校验，仅用于本地测试
+
+	Transport Transport
+
+	RetryPolicy *RetryPolicy
+
+	RateLimiter *RateLimiter
+
+	CircuitBreaker *CircuitBreaker
+
+	Interceptors []Interceptor
+
+	Metrics Metrics
+
+	CancelReasonsTTL time.Duration // 取消原因列表缓存时间
+
+	ValidateRequests bool // 发单和查询运费前校验请求参数
 }
 
 // Option the option is an ImDada option.
@@ -80,6 +106,28 @@ func WithGateway(gateway string) Option {
 	}
 }
 
+// WithCancelReasonsTTL sets how long Client.CancelReasons caches the cancel reasons, default is one hour.
+func WithCancelReasonsTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.CancelReasonsTTL = ttl
+	}
+}
+
+// WithRequestValidation makes CreateOrder, ReCreateOrder and QueryDeliverFee validate the request
+// before it is signed and sent, an invalid request returns a domain.ValidationErrors.
+func WithRequestValidation() Option {
+	return func(o *options) {
+		o.ValidateRequests = true
+	}
+}
+
+// WithSandbox switches the gateway to the test environment, see Client.Sandbox for the simulation APIs.
+func WithSandbox() Option {
+	return func(o *options) {
+		o.Gateway = sandboxGateway
+	}
+}
+
 // WithTimeOut sets the timeout.
 func WithTimeOut(timeout time.Duration) Option {
 	return func(o *options) {
@@ -129,11 +177,117 @@ func WithLevel(level Level) Option {
 	}
 }
 
+// WithMaxConnsPerHost sets the maximum number of connections per host.
+func WithMaxConnsPerHost(maxConns int) Option {
+	return func(o *options) {
+		o.MaxConnsPerHost = maxConns
+	}
+}
+
+// WithIdleTimeout sets how long an idle keep-alive connection is kept in the pool.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.IdleTimeout = timeout
+	}
+}
+
+// WithReadTimeout sets the read timeout.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.ReadTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the write timeout.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.WriteTimeout = timeout
+	}
+}
+
+// WithDialer sets a custom dialer.
+func WithDialer(dialer network.Dialer) Option {
+	return func(o *options) {
+		o.Dialer = dialer
+	}
+}
+
+// WithProxy sets the proxy, e.g. protocol.ProxyURI(protocol.ParseURI("http://127.0.0.1:8080")).
+func WithProxy(proxy protocol.Proxy) Option {
+	return func(o *options) {
+		o.Proxy = proxy
+	}
+}
+
+// WithTLSConfig sets the tls config, e.g. a custom root CA pool or client certificates.
+// Certificate verification stays on unless WithInsecureSkipVerify is also given.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.TLSConfig = cfg
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server certificate.
+// It is only meant for local stand-ins of the gateway and must not be used in production.
+func WithInsecureSkipVerify() Option {
+	return func(o *options) {
+		o.InsecureSkipVerify = true
+	}
+}
+
+// WithTransport sets the transport used to send requests to the gateway.
+// The connection pool and tls options only apply to the default hertz transport.
+// The transport belongs to the caller, Client.Close does not close it.
+func WithTransport(transport Transport) Option {
+	return func(o *options) {
+		o.Transport = transport
+	}
+}
+
+// WithRetryPolicy sets the retry policy, see DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.RetryPolicy = &policy
+	}
+}
+
+// WithRateLimiter sets the client side rate limiter, see NewRateLimiter and SharedRateLimiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.RateLimiter = limiter
+	}
+}
+
+// WithCircuitBreaker sets the circuit breaker around the gateway, see NewCircuitBreaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *options) {
+		o.CircuitBreaker = breaker
+	}
+}
+
+// WithInterceptors appends interceptors around every API call, they run in the given order
+// after the built-in logging interceptor.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *options) {
+		o.Interceptors = append(o.Interceptors, interceptors...)
+	}
+}
+
+// WithMetrics sets the metrics receiving the measurements of the client.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.Metrics = m
+	}
+}
+
 // Client is the ImDada client.
+// A Client is safe for concurrent use by multiple goroutines.
 type Client struct {
-	request  *domain.Request
-	response *protocol.Response
-	log      Logger
-	op       options
-	gateway  string
+	log          Logger
+	op           options
+	transport    Transport
+	ownTransport bool
+	handler      Handler
+	err          error
+	reasons      reasonCache
 }
@@ -0,0 +1,185 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// Business is the business category of a shop.
+type Business int
+
+// Business category.
+const (
+	BusinessFoodSnacks            Business = 1  // 食品小吃
+	BusinessDrink                 Business = 2  // 饮料
+	BusinessFlowersAndGreenery    Business = 3  // 鲜花绿植
+	BusinessOther                 Business = 5  // 其他
+	BusinessPrintingTicketing     Business = 8  // 文印票务
+	BusinessConvenienceStores     Business = 9  // 便利店
+	BusinessFreshFruit            Business = 13 // 水果生鲜
+	BusinessIntraCityECommerce    Business = 19 // 同城电商
+	BusinessMedicine              Business = 20 // 医药
+	BusinessCake                  Business = 21 // 蛋糕
+	BusinessWine                  Business = 24 // 酒品
+	BusinessSmallCommodityMarkets Business = 25 // 小商品市场
+	BusinessClothing              Business = 26 // 服装
+	BusinessAutoRepairParts       Business = 27 // 汽修零配
+	BusinessDigitalAppliances     Business = 28 // 数码家电
+	BusinessCrayfishBBQ           Business = 29 // 小龙虾/烧烤
+	BusinessSupermarket           Business = 31 // 超市
+	BusinessChafingDish           Business = 51 // 火锅
+	BusinessPersonalCareMakeup    Business = 53 // 个护美妆
+	BusinessMother                Business = 55 // 母婴
+	BusinessHomeTextiles          Business = 57 // 家居家纺
+	BusinessCellPhone             Business = 59 // 手机
+	BusinessHome                  Business = 61 // 家装
+	BusinessAdultProducts         Business = 63 // 成人用品
+	BusinessCampus                Business = 65 // 校园
+	BusinessHighEndMarket         Business = 66 // 高端市场
+)
+
+// businessNames are the Chinese and English names of the categories.
+var businessNames = map[Business][2]string{
+	BusinessFoodSnacks:            {"食品小吃", "FoodSnacks"},
+	BusinessDrink:                 {"饮料", "Drink"},
+	BusinessFlowersAndGreenery:    {"鲜花绿植", "FlowersAndGreenery"},
+	BusinessOther:                 {"其他", "Other"},
+	BusinessPrintingTicketing:     {"文印票务", "PrintingTicketing"},
+	BusinessConvenienceStores:     {"便利店", "ConvenienceStores"},
+	BusinessFreshFruit:            {"水果生鲜", "FreshFruit"},
+	BusinessIntraCityECommerce:    {"同城电商", "IntraCityECommerce"},
+	BusinessMedicine:              {"医药", "Medicine"},
+	BusinessCake:                  {"蛋糕", "Cake"},
+	BusinessWine:                  {"酒品", "Wine"},
+	BusinessSmallCommodityMarkets: {"小商品市场", "SmallCommodityMarkets"},
+	BusinessClothing:              {"服装", "Clothing"},
+	BusinessAutoRepairParts:       {"汽修零配", "AutoRepairParts"},
+	BusinessDigitalAppliances:     {"数码家电", "DigitalAppliances"},
+	BusinessCrayfishBBQ:           {"小龙虾/烧烤", "CrayfishBBQ"},
+	BusinessSupermarket:           {"超市", "Supermarket"},
+	BusinessChafingDish:           {"火锅", "ChafingDish"},
+	BusinessPersonalCareMakeup:    {"个护美妆", "PersonalCareMakeup"},
+	BusinessMother:                {"母婴", "Mother"},
+	BusinessHomeTextiles:          {"家居家纺", "HomeTextiles"},
+	BusinessCellPhone:             {"手机", "CellPhone"},
+	BusinessHome:                  {"家装", "Home"},
+	BusinessAdultProducts:         {"成人用品", "AdultProducts"},
+	BusinessCampus:                {"校园", "Campus"},
+	BusinessHighEndMarket:         {"高端市场", "HighEndMarket"},
+}
+
+// ErrUnsupportedBusiness is returned for a business category unknown to the gateway.
+var ErrUnsupportedBusiness = errors.New("domain: unsupported business")
+
+// Valid reports whether the category is supported by the gateway.
+func (b Business) Valid() bool {
+	_, ok := businessNames[b]
+	return ok
+}
+
+// String returns the Chinese name of the category.
+func (b Business) String() string {
+	if names, ok := businessNames[b]; ok {
+		return names[0]
+	}
+	return fmt.Sprintf("Business(%d)", int(b))
+}
+
+// EnglishName returns the English name of the category.
+func (b Business) EnglishName() string {
+	if names, ok := businessNames[b]; ok {
+		return names[1]
+	}
+	return fmt.Sprintf("Business(%d)", int(b))
+}
+
+// ParseBusiness parses a category from its code, Chinese name or English name, the English name is case-insensitive.
+func ParseBusiness(s string) (Business, error) {
+	s = strings.TrimSpace(s)
+	if code, err := strconv.Atoi(s); err == nil {
+		if b := Business(code); b.Valid() {
+			return b, nil
+		}
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedBusiness, s)
+	}
+	for b, names := range businessNames {
+		if s == names[0] || strings.EqualFold(s, names[1]) {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnsupportedBusiness, s)
+}
+
+// MarshalJSON encodes the category as its code.
+func (b Business) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(b))), nil
+}
+
+// UnmarshalJSON decodes the category from its code, or from a string accepted by ParseBusiness.
+// null leaves the category unchanged.
+func (b *Business) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := sonic.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		v, err := ParseBusiness(s)
+		if err != nil {
+			return err
+		}
+		*b = v
+		return nil
+	}
+	code, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("domain: invalid business %s: %w", data, err)
+	}
+	*b = Business(code)
+	return nil
+}
+
+// Validate checks the business category of every shop.
+func (r ShopCreateRequest) Validate() error {
+	for i, item := range r {
+		if item == nil {
+			continue
+		}
+		if !item.Business.Valid() {
+			return fmt.Errorf("%w: %d of shop %d(%s)", ErrUnsupportedBusiness, int(item.Business), i, item.OriginShopID)
+		}
+	}
+	return nil
+}
+
+// Validate checks the business category, a zero one keeps the current category.
+func (r *ShopUpdateRequest) Validate() error {
+	if r.Business != 0 && !r.Business.Valid() {
+		return fmt.Errorf("%w: %d of shop %s", ErrUnsupportedBusiness, int(r.Business), r.OriginShopID)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestParseBusiness(t *testing.T) {
+	tests := []struct {
+		in   string
+		want domain.Business
+		err  bool
+	}{
+		{"20", domain.BusinessMedicine, false},
+		{" 1 ", domain.BusinessFoodSnacks, false},
+		{"医药", domain.BusinessMedicine, false},
+		{"小龙虾/烧烤", domain.BusinessCrayfishBBQ, false},
+		{"Medicine", domain.BusinessMedicine, false},
+		{"medicine", domain.BusinessMedicine, false},
+		{"CONVENIENCESTORES", domain.BusinessConvenienceStores, false},
+		{"4", 0, true},
+		{"0", 0, true},
+		{"Pharmacy", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := domain.ParseBusiness(tt.in)
+		if tt.err {
+			if !errors.Is(err, domain.ErrUnsupportedBusiness) {
+				t.Errorf("ParseBusiness(%q) = %v, %v, want ErrUnsupportedBusiness", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBusiness(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestBusinessNames(t *testing.T) {
+	if got := domain.BusinessCake.String(); got != "蛋糕" {
+		t.Errorf("String() = %q, want 蛋糕", got)
+	}
+	if got := domain.BusinessCake.EnglishName(); got != "Cake" {
+		t.Errorf("EnglishName() = %q, want Cake", got)
+	}
+	if b := domain.Business(4); b.Valid() || b.String() != "Business(4)" {
+		t.Errorf("Business(4) is valid %t, named %q", b.Valid(), b.String())
+	}
+}
+
+func TestBusinessJSON(t *testing.T) {
+	data, err := sonic.Marshal(&domain.ShopUpdateRequest{OriginShopID: "s1", Business: domain.BusinessWine})
+	if err != nil || string(data) != `{"business":24,"origin_shop_id":"s1"}` {
+		t.Errorf("encoded %s, %v", data, err)
+	}
+
+	tests := []struct {
+		in   string
+		want domain.Business
+		err  bool
+	}{
+		{`24`, domain.BusinessWine, false},
+		{`"24"`, domain.BusinessWine, false},
+		{`"酒品"`, domain.BusinessWine, false},
+		{`"wine"`, domain.BusinessWine, false},
+		{`null`, domain.BusinessOther, false}, // null keeps the value
+		{`"unknown"`, 0, true},
+		{`true`, 0, true},
+	}
+	for _, tt := range tests {
+		b := domain.BusinessOther
+		err := b.UnmarshalJSON([]byte(tt.in))
+		if tt.err {
+			if err == nil {
+				t.Errorf("UnmarshalJSON(%s) = %v, want an error", tt.in, b)
+			}
+			continue
+		}
+		if err != nil || b != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %v, %v, want %v", tt.in, b, err, tt.want)
+		}
+	}
+
+	var resp domain.ShopQueryResponse
+	if err = sonic.UnmarshalString(`{"status":"success","result":{"origin_shop_id":"s1","business":null}}`, &resp); err != nil {
+		t.Fatalf("decoding a null business: %v", err)
+	}
+	if resp.Result == nil || resp.Result.OriginShopID != "s1" || resp.Result.Business != 0 {
+		t.Errorf("decoded %+v", resp.Result)
+	}
+
+	// A decoded category encodes back to the same code.
+	item := domain.ShopQueryItem{OriginShopID: "s1", Business: domain.BusinessCampus}
+	if data, err = sonic.Marshal(&item); err != nil {
+		t.Fatal(err)
+	}
+	var decoded domain.ShopQueryItem
+	if err = sonic.Unmarshal(data, &decoded); err != nil || decoded != item {
+		t.Errorf("round trip of %s = %+v, %v", data, decoded, err)
+	}
+}
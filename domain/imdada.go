@@ -19,6 +19,10 @@
 // Package domain is the domain of ImDaDa.
 package domain
 
+import (
+	"fmt"
+)
+
 // Request is the request of ImDaDa.
 type Request struct {
 	AppKey    string `json:"app_key"`
@@ -30,13 +34,60 @@ type Request struct {
 	Timestamp int64  `json:"timestamp"`
 }
 
-// Response is the response of ImDaDa.
-type Response struct {
-	Status    string      `json:"status"`
-	ErrorCode int         `json:"errorCode"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Result    interface{} `json:"result"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
+// StatusSuccess is the status of a successful response.
+const StatusSuccess = "success"
+
+// Response is the response of ImDaDa, T is the type of the result.
+type Response[T any] struct {
+	Status    string `json:"status"` // 响应状态，成功为"success"，失败为"fail"
+	Result    T      `json:"result"` // 响应结果，JSON对象，详见具体的接口描述
+	Code      int    `json:"code"`   // 响应返回码，参考接口返回码
+	Msg       string `json:"msg"`    // 响应描述
+	Success   bool   `json:"success"`
+	Fail      bool   `json:"fail"`
+	ErrorCode int    `json:"errorCode"` // 错误编码，与code一致
+}
+
+// OK reports whether the response is successful.
+func (r *Response[T]) OK() bool {
+	return r.Status == StatusSuccess && r.Code == 0
+}
+
+// Err returns a *ResponseError when the response is not successful, otherwise nil.
+// The error matches the sentinel errors of the dadago package with errors.Is, e.g. dadago.ErrDuplicateOriginID,
+// and dadago.ErrorClassOf classifies it.
+func (r *Response[T]) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return &ResponseError{
+		Status:    r.Status,
+		Code:      r.Code,
+		ErrorCode: r.ErrorCode,
+		Msg:       r.Msg,
+	}
+}
+
+// ResponseError is the error of a failed response.
+type ResponseError struct {
+	Status    string
+	Code      int
+	ErrorCode int
+	Msg       string
+}
+
+// Error implements the error interface.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("status: %s, code: %d, errorCode: %d, msg: %s", e.Status, e.Code, e.ErrorCode, e.Msg)
+}
+
+// Is reports whether target is the error of the return code, target tells its code with
+// a ResponseCode method like the sentinel errors of the dadago package.
+func (e *ResponseError) Is(target error) bool {
+	t, ok := target.(interface{ ResponseCode() int })
+	if !ok {
+		return false
+	}
+	code := t.ResponseCode()
+	return code == e.Code || code == e.ErrorCode
 }
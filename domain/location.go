@@ -0,0 +1,88 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"github.com/houseme/imdadago/geo"
+)
+
+// SetReceiverLocation sets the receiver coordinate, converting it to GCJ-02 from its datum.
+func (r *OrdersCreateRequest) SetReceiverLocation(p geo.Point) error {
+	g, err := p.GCJ02()
+	if err != nil {
+		return err
+	}
+	r.ReceiverLat, r.ReceiverLng = g.Lat, g.Lng
+	return nil
+}
+
+// ReceiverLocation returns the receiver coordinate.
+func (r *OrdersCreateRequest) ReceiverLocation() geo.Point {
+	return geo.New(r.ReceiverLat, r.ReceiverLng, geo.GCJ02)
+}
+
+// SetReceiverLocation sets the receiver coordinate, converting it to GCJ-02 from its datum.
+func (r *DeliverFeeQueryRequest) SetReceiverLocation(p geo.Point) error {
+	g, err := p.GCJ02()
+	if err != nil {
+		return err
+	}
+	r.ReceiverLat, r.ReceiverLng = g.Lat, g.Lng
+	return nil
+}
+
+// ReceiverLocation returns the receiver coordinate.
+func (r *DeliverFeeQueryRequest) ReceiverLocation() geo.Point {
+	return geo.New(r.ReceiverLat, r.ReceiverLng, geo.GCJ02)
+}
+
+// SetLocation sets the shop coordinate, converting it to GCJ-02 from its datum.
+func (r *ShopCreateItem) SetLocation(p geo.Point) error {
+	g, err := p.GCJ02()
+	if err != nil {
+		return err
+	}
+	r.Lat, r.Lng = g.Lat, g.Lng
+	return nil
+}
+
+// SetLocation sets the shop coordinate, converting it to GCJ-02 from its datum.
+func (r *ShopUpdateRequest) SetLocation(p geo.Point) error {
+	g, err := p.GCJ02()
+	if err != nil {
+		return err
+	}
+	r.Lat, r.Lng = g.Lat, g.Lng
+	return nil
+}
+
+// Location returns the shop coordinate.
+func (r *ShopQueryItem) Location() geo.Point {
+	return geo.New(r.Lat, r.Lng, geo.GCJ02)
+}
+
+// TransporterLocation parses the transporter coordinate.
+func (r *OrdersQueryResult) TransporterLocation() (geo.Point, error) {
+	return geo.Parse(r.TransporterLat, r.TransporterLng, geo.GCJ02)
+}
+
+// TransporterLocation parses the transporter coordinate.
+func (r *OuterPositionInfo) TransporterLocation() (geo.Point, error) {
+	return geo.Parse(r.TransporterLat, r.TransporterLng, geo.GCJ02)
+}
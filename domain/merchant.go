@@ -25,15 +25,7 @@ type CityListQueryRequest struct {
 }
 
 // CityListQueryResponse is the response of CityListQuery.
-type CityListQueryResponse struct {
-	Status    string      `json:"status"`
-	Result    []*CityItem `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Fail      bool        `json:"fail"`
-	Success   bool        `json:"success"`
-	ErrorCode int         `json:"errorCode"`
-}
+type CityListQueryResponse = Response[[]*CityItem]
 
 // CityItem is the item of CityListQuery.
 type CityItem struct {
@@ -52,16 +44,9 @@ type MerchantCreateRequest struct {
 	Mobile            string `json:"mobile"`
 }
 
-// MerchantCreateResponse is the response of MerchantCreate.
-type MerchantCreateResponse struct {
-	Status    string `json:"status"`
-	Result    int    `json:"result"` // 商户id
-	Code      int    `json:"code"`
-	Msg       string `json:"msg"`
-	Fail      bool   `json:"fail"`
-	Success   bool   `json:"success"`
-	ErrorCode int    `json:"errorCode"`
-}
+// MerchantCreateResponse is the response of MerchantCreate, the result is the merchant id.
+// 返回结果为商户id
+type MerchantCreateResponse = Response[int]
 
 // ShopCreateRequest is the request of ShopCreate.
 type ShopCreateRequest []*ShopCreateItem
@@ -69,28 +54,22 @@ type ShopCreateRequest []*ShopCreateItem
 // ShopCreateItem is the item of ShopCreate.
 // See: http://newopen.imdada.cn/#/development/file/shopAdd
 type ShopCreateItem struct {
-	StationName    string  `json:"station_name"`
-	OriginShopID   string  `json:"origin_shop_id,omitempty"`
-	StationAddress string  `json:"station_address"`
-	ContactName    string  `json:"contact_name"`
-	Business       int     `json:"business"`
-	Lng            float64 `json:"lng"`
-	Phone          string  `json:"phone"`
-	Lat            float64 `json:"lat"`
-	IDCard         string  `json:"id_card,omitempty"`
-	Password       string  `json:"password,omitempty"`
-	Username       string  `json:"username,omitempty"`
-	SettlementType int     `json:"settlement_type,omitempty"`
+	StationName    string   `json:"station_name"`
+	OriginShopID   string   `json:"origin_shop_id,omitempty"`
+	StationAddress string   `json:"station_address"`
+	ContactName    string   `json:"contact_name"`
+	Business       Business `json:"business"`
+	Lng            float64  `json:"lng"`
+	Phone          string   `json:"phone"`
+	Lat            float64  `json:"lat"`
+	IDCard         string   `json:"id_card,omitempty"`
+	Password       string   `json:"password,omitempty"`
+	Username       string   `json:"username,omitempty"`
+	SettlementType int      `json:"settlement_type,omitempty"`
 }
 
 // ShopCreateResponse is the response of ShopCreate.
-type ShopCreateResponse struct {
-	Status    string            `json:"status"`
-	Result    *ShopCreateResult `json:"result"`
-	Code      int               `json:"code"`
-	Msg       string            `json:"msg"`
-	ErrorCode int               `json:"errorCode"`
-}
+type ShopCreateResponse = Response[*ShopCreateResult]
 
 // ShopCreateResult is the result of ShopCreate.
 type ShopCreateResult struct {
@@ -101,16 +80,16 @@ type ShopCreateResult struct {
 
 // ShopCreateSuccessItem is the item of ShopCreateSuccess.
 type ShopCreateSuccessItem struct {
-	Phone          string  `json:"phone"`
-	Business       int     `json:"business"`
-	Lng            float64 `json:"lng"`
-	Lat            float64 `json:"lat"`
-	StationName    string  `json:"stationName"`
-	OriginShopID   string  `json:"originShopId"`
-	ContactName    string  `json:"contactName"`
-	StationAddress string  `json:"stationAddress"`
-	CityName       string  `json:"cityName"`
-	AreaName       string  `json:"areaName"`
+	Phone          string   `json:"phone"`
+	Business       Business `json:"business"`
+	Lng            float64  `json:"lng"`
+	Lat            float64  `json:"lat"`
+	StationName    string   `json:"stationName"`
+	OriginShopID   string   `json:"originShopId"`
+	ContactName    string   `json:"contactName"`
+	StationAddress string   `json:"stationAddress"`
+	CityName       string   `json:"cityName"`
+	AreaName       string   `json:"areaName"`
 }
 
 // ShopCreateFailedItem is the item of ShopCreateFailed.
@@ -122,26 +101,19 @@ type ShopCreateFailedItem struct {
 
 // ShopUpdateRequest is the request of ShopUpdate.
 type ShopUpdateRequest struct {
-	Business       int     `json:"business,omitempty"`
-	ContactName    string  `json:"contact_name,omitempty"`
-	Lat            float64 `json:"lat,omitempty"`
-	Lng            float64 `json:"lng,omitempty"`
-	OriginShopID   string  `json:"origin_shop_id"`
-	Phone          string  `json:"phone,omitempty"`
-	StationAddress string  `json:"station_address,omitempty"`
-	StationName    string  `json:"station_name,omitempty"`
+	Business       Business `json:"business,omitempty"`
+	ContactName    string   `json:"contact_name,omitempty"`
+	Lat            float64  `json:"lat,omitempty"`
+	Lng            float64  `json:"lng,omitempty"`
+	OriginShopID   string   `json:"origin_shop_id"`
+	Phone          string   `json:"phone,omitempty"`
+	StationAddress string   `json:"station_address,omitempty"`
+	StationName    string   `json:"station_name,omitempty"`
 }
 
-// ShopUpdateResponse is the response of ShopUpdate.
-type ShopUpdateResponse struct {
-	Status    string `json:"status"`
-	Result    int    `json:"result"` // 商户id
-	Code      int    `json:"code"`
-	Msg       string `json:"msg"`
-	Fail      bool   `json:"fail"`
-	Success   bool   `json:"success"`
-	ErrorCode int    `json:"errorCode"`
-}
+// ShopUpdateResponse is the response of ShopUpdate, the result is the merchant id.
+// 返回结果为商户id
+type ShopUpdateResponse = Response[int]
 
 // ShopQueryRequest is the request of ShopQuery.
 type ShopQueryRequest struct {
@@ -149,29 +121,21 @@ type ShopQueryRequest struct {
 }
 
 // ShopQueryResponse is the response of ShopQuery.
-type ShopQueryResponse struct {
-	Status    string         `json:"status"`
-	Result    *ShopQueryItem `json:"result"`
-	Code      int            `json:"code"`
-	Msg       string         `json:"msg"`
-	Fail      bool           `json:"fail"`
-	Success   bool           `json:"success"`
-	ErrorCode int            `json:"errorCode"`
-}
+type ShopQueryResponse = Response[*ShopQueryItem]
 
 // ShopQueryItem is the item of ShopQuery.
 type ShopQueryItem struct {
-	StationName    string  `json:"station_name"`
-	AreaName       string  `json:"area_name"`
-	StationAddress string  `json:"station_address"`
-	CityName       string  `json:"city_name"`
-	ContactName    string  `json:"contact_name"`
-	OriginShopID   string  `json:"origin_shop_id"`
-	Business       int     `json:"business"`
-	Lng            float64 `json:"lng"`
-	Phone          string  `json:"phone"`
-	IDCard         string  `json:"id_card"`
-	Lat            float64 `json:"lat"`
-	Status         int     `json:"status"`
-	ApproveStatus  int     `json:"approveStatus"`
+	StationName    string   `json:"station_name"`
+	AreaName       string   `json:"area_name"`
+	StationAddress string   `json:"station_address"`
+	CityName       string   `json:"city_name"`
+	ContactName    string   `json:"contact_name"`
+	OriginShopID   string   `json:"origin_shop_id"`
+	Business       Business `json:"business"`
+	Lng            float64  `json:"lng"`
+	Phone          string   `json:"phone"`
+	IDCard         string   `json:"id_card"`
+	Lat            float64  `json:"lat"`
+	Status         int      `json:"status"`
+	ApproveStatus  int      `json:"approveStatus"`
 }
@@ -0,0 +1,76 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"github.com/bytedance/sonic"
+)
+
+// UnmarshalJSON decodes the message, the message body is a JSON string or a JSON object.
+func (r *OrdersTransporterCancelAsyncRequest) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		MessageType int                    `json:"messageType"`
+		MessageBody sonic.NoCopyRawMessage `json:"messageBody"`
+	}
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.MessageType = raw.MessageType
+	return unmarshalMessageBody(raw.MessageBody, &r.MessageBody)
+}
+
+// MarshalJSON encodes the message body as a JSON string, as required by the gateway.
+func (r OrdersTransporterCancelAsyncConfirmRequest) MarshalJSON() ([]byte, error) {
+	body, err := sonic.MarshalString(&r.MessageBody)
+	if err != nil {
+		return nil, err
+	}
+	return sonic.Marshal(&struct {
+		MessageType int    `json:"messageType"`
+		MessageBody string `json:"messageBody"`
+	}{r.MessageType, body})
+}
+
+// UnmarshalJSON decodes the request, the message body is a JSON string or a JSON object.
+func (r *OrdersTransporterCancelAsyncConfirmRequest) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		MessageType int                    `json:"messageType"`
+		MessageBody sonic.NoCopyRawMessage `json:"messageBody"`
+	}
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.MessageType = raw.MessageType
+	return unmarshalMessageBody(raw.MessageBody, &r.MessageBody)
+}
+
+// unmarshalMessageBody decodes a message body encoded as a JSON string or a JSON object.
+func unmarshalMessageBody(data []byte, v any) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == '"' {
+		var s string
+		if err := sonic.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return sonic.Unmarshal(data, v)
+}
@@ -0,0 +1,135 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of money in fen (分), the gateway encodes it in yuan (元),
+// e.g. Money(1234) is 12.34 in JSON. Sums of Money are exact, unlike float64.
+type Money int64
+
+// Yuan converts an amount in yuan to Money, rounding to the nearest fen.
+func Yuan(yuan float64) Money {
+	return Money(math.Round(yuan * 100))
+}
+
+// ErrInvalidMoney is returned when an amount is not a decimal in yuan.
+var ErrInvalidMoney = errors.New("domain: invalid money")
+
+// ParseMoney parses a decimal amount in yuan, e.g. "12.34".
+// Digits beyond the fen are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.Abs(f) >= math.MaxInt64/100 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+		}
+		return Yuan(f), nil
+	}
+	num, neg := s, false
+	if num != "" && (num[0] == '-' || num[0] == '+') {
+		num, neg = num[1:], num[0] == '-'
+	}
+	whole, frac, _ := strings.Cut(num, ".")
+	if whole == "" && frac == "" || !digits(whole) || !digits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	var fen int64
+	if whole != "" {
+		yuan, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || yuan > math.MaxInt64/100 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+		}
+		fen = yuan * 100
+	}
+	frac += "000"
+	cents := int64(frac[0]-'0')*10 + int64(frac[1]-'0')
+	if frac[2] >= '5' {
+		cents++
+	}
+	if fen > math.MaxInt64-cents {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	fen += cents
+	if neg {
+		fen = -fen
+	}
+	return Money(fen), nil
+}
+
+// digits reports whether s only contains decimal digits.
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Fen returns the amount in fen.
+func (m Money) Fen() int64 {
+	return int64(m)
+}
+
+// Yuan returns the amount in yuan as a float64, for code migrating from float64 amounts.
+func (m Money) Yuan() float64 {
+	return float64(m) / 100
+}
+
+// String returns the amount in yuan with two decimals, e.g. "12.30".
+func (m Money) String() string {
+	sign, fen := "", int64(m)
+	if fen < 0 {
+		sign, fen = "-", -fen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, fen/100, fen%100)
+}
+
+// MarshalJSON encodes the amount as a number in yuan.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes the amount from a number or a string in yuan.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if s = s[1 : len(s)-1]; s == "" {
+			*m = 0
+			return nil
+		}
+	}
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want domain.Money
+		err  bool
+	}{
+		{"12.34", 1234, false},
+		{"12.3", 1230, false},
+		{"12", 1200, false},
+		{"12.", 1200, false},
+		{".5", 50, false},
+		{"0", 0, false},
+		{" 7.01 ", 701, false},
+		{"+1.2", 120, false},
+		{"-12.34", -1234, false},
+		{"0.125", 13, false},
+		{"0.124", 12, false},
+		{"0.1249", 12, false},
+		{"0.005", 1, false},
+		{"0.0049", 0, false},
+		{"-0.005", -1, false},
+		{"-0.125", -13, false},
+		{"19.999", 2000, false},
+		{"1.2e1", 1200, false},
+		{"1E-2", 1, false},
+		{"92233720368547758.07", math.MaxInt64, false},
+		{"-92233720368547758.07", -math.MaxInt64, false},
+		{"92233720368547758.08", 0, true},
+		{"92233720368547758.075", 0, true},
+		{"92233720368547759", 0, true},
+		{"99999999999999999999", 0, true},
+		{"1e20", 0, true},
+		{"-1e20", 0, true},
+		{"1e400", 0, true},
+		{"", 0, true},
+		{"-", 0, true},
+		{".", 0, true},
+		{"1.2.3", 0, true},
+		{"1,2", 0, true},
+		{"--1", 0, true},
+		{"abc", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"0x10", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := domain.ParseMoney(tt.in)
+		if tt.err {
+			if !errors.Is(err, domain.ErrInvalidMoney) {
+				t.Errorf("ParseMoney(%q) = %d, %v, want ErrInvalidMoney", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		in   domain.Money
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{1230, "12.30"},
+		{1234, "12.34"},
+		{-5, "-0.05"},
+		{-1234, "-12.34"},
+		{domain.Yuan(12.34), "12.34"},
+		{domain.Yuan(0.1 + 0.2), "0.30"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want domain.Money
+		err  bool
+	}{
+		{`12.34`, 1234, false},
+		{`"12.34"`, 1234, false},
+		{`12`, 1200, false},
+		{`"12"`, 1200, false},
+		{`-0.5`, -50, false},
+		{`0.125`, 13, false},
+		{`""`, 0, false},
+		{`null`, 7, false}, // null keeps the value
+		{`1.5e2`, 15000, false},
+		{`"abc"`, 0, true},
+		{`true`, 0, true},
+		{`92233720368547758.08`, 0, true},
+	}
+	for _, tt := range tests {
+		m := domain.Money(7)
+		err := m.UnmarshalJSON([]byte(tt.in))
+		if tt.err {
+			if !errors.Is(err, domain.ErrInvalidMoney) {
+				t.Errorf("UnmarshalJSON(%s) = %d, %v, want ErrInvalidMoney", tt.in, m, err)
+			}
+			continue
+		}
+		if err != nil || m != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %d, %v, want %d", tt.in, m, err, tt.want)
+		}
+	}
+
+	var req domain.OrdersAddTipRequest
+	if err := sonic.UnmarshalString(`{"order_id":"1","tips":"1.50"}`, &req); err != nil || req.Tips != 150 {
+		t.Errorf("decoded tips %d, %v, want 150", req.Tips, err)
+	}
+	data, err := sonic.Marshal(&domain.OrdersAddTipRequest{OrderID: "1", Tips: 150})
+	if err != nil || string(data) != `{"order_id":"1","tips":1.50}` {
+		t.Errorf("encoded %s, %v", data, err)
+	}
+}
+
+func TestRechargeRequestAmountIsString(t *testing.T) {
+	tests := []struct {
+		amount domain.Money
+		want   string
+	}{
+		{domain.Yuan(100), "100.00"},
+		{1234, "12.34"},
+		{5, "0.05"},
+		{0, "0.00"},
+	}
+	for _, tt := range tests {
+		data, err := sonic.Marshal(&domain.RechargeRequest{Amount: tt.amount, Category: "PC"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]any
+		if err = sonic.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if amount, ok := fields["amount"].(string); !ok || amount != tt.want || fields["category"] != "PC" {
+			t.Errorf("Marshal(amount %d) = %s, want the string amount %q", int64(tt.amount), data, tt.want)
+		}
+		var back domain.RechargeRequest
+		if err = sonic.Unmarshal(data, &back); err != nil || back.Amount != tt.amount {
+			t.Errorf("Unmarshal(%s) = %d, %v, want %d", data, back.Amount, err, tt.amount)
+		}
+	}
+}
@@ -25,17 +25,17 @@ package domain
 type OrdersCreateRequest struct {
 	ShopNo                string         `json:"shop_no"`
 	OriginID              string         `json:"origin_id"`
-	CargoPrice            float64        `json:"cargo_price"`
+	CargoPrice            Money          `json:"cargo_price"`
 	IsPrepay              int            `json:"is_prepay"`
 	ReceiverName          string         `json:"receiver_name"`
 	ReceiverAddress       string         `json:"receiver_address"`
-	ReceiverLat           float64        `json:"receiver_lat"`
-	ReceiverLng           float64        `json:"receiver_lng"`
+	ReceiverLat           float64        `json:"receiver_lat"` // GCJ-02 坐标，见 SetReceiverLocation
+	ReceiverLng           float64        `json:"receiver_lng"` // GCJ-02 坐标，见 SetReceiverLocation
 	Callback              string         `json:"callback"`
 	CargoWeight           float64        `json:"cargo_weight"`
 	ReceiverPhone         string         `json:"receiver_phone,omitempty"`
 	ReceiverTel           string         `json:"receiver_tel,omitempty"`
-	Tips                  float64        `json:"tips,omitempty"` // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
+	Tips                  Money          `json:"tips,omitempty"` // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
 	Info                  string         `json:"info,omitempty"`
 	CargoType             int            `json:"cargo_type,omitempty"`
 	CargoNum              int            `json:"cargo_num,omitempty"`
@@ -62,24 +62,16 @@ type ProductItem struct {
 }
 
 // OrdersCreateResponse is the response of orders/create.
-type OrdersCreateResponse struct {
-	Status    string              `json:"status"` // 响应状态，成功为"success"，失败为"fail"
-	Result    *OrdersCreateResult `json:"result"` // 响应结果，JSON对象，详见具体的接口描述
-	Code      int                 `json:"code"`   // 响应返回码，参考接口返回码
-	Msg       string              `json:"msg"`    // 响应描述
-	Success   bool                `json:"success"`
-	Fail      bool                `json:"fail"`
-	ErrorCode int                 `json:"errorCode"` // 错误编码，与code一致
-}
+type OrdersCreateResponse = Response[*OrdersCreateResult]
 
 // OrdersCreateResult is the result of orders/create.
 type OrdersCreateResult struct {
 	Distance     float64 `json:"distance"`     // 配送距离(单位：米)
-	Fee          float64 `json:"fee"`          // 实际运费(单位：元)，运费减去优惠券费用
-	DeliverFee   float64 `json:"deliverFee"`   // 运费(单位：元)
-	InsuranceFee float64 `json:"insuranceFee"` // 保价费(单位：元)
-	CouponFee    float64 `json:"couponFee"`    // 优惠券费用(单位：元)
-	Tips         float64 `json:"tips"`         // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
+	Fee          Money   `json:"fee"`          // 实际运费(单位：元)，运费减去优惠券费用
+	DeliverFee   Money   `json:"deliverFee"`   // 运费(单位：元)
+	InsuranceFee Money   `json:"insuranceFee"` // 保价费(单位：元)
+	CouponFee    Money   `json:"couponFee"`    // 优惠券费用(单位：元)
+	Tips         Money   `json:"tips"`         // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
 }
 
 // DeliverFeeQueryRequest is the request of deliver_fee/query.
@@ -87,17 +79,17 @@ type OrdersCreateResult struct {
 type DeliverFeeQueryRequest struct {
 	ShopNo                string         `json:"shop_no"`
 	OriginID              string         `json:"origin_id"`
-	CargoPrice            float64        `json:"cargo_price"`
+	CargoPrice            Money          `json:"cargo_price"`
 	IsPrepay              int            `json:"is_prepay"`
 	ReceiverName          string         `json:"receiver_name"`
 	ReceiverAddress       string         `json:"receiver_address"`
-	ReceiverLat           float64        `json:"receiver_lat"`
-	ReceiverLng           float64        `json:"receiver_lng"`
+	ReceiverLat           float64        `json:"receiver_lat"` // GCJ-02 坐标，见 SetReceiverLocation
+	ReceiverLng           float64        `json:"receiver_lng"` // GCJ-02 坐标，见 SetReceiverLocation
 	Callback              string         `json:"callback"`
 	CargoWeight           float64        `json:"cargo_weight"`
 	ReceiverPhone         string         `json:"receiver_phone,omitempty"`
 	ReceiverTel           string         `json:"receiver_tel,omitempty"`
-	Tips                  float64        `json:"tips,omitempty"` // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
+	Tips                  Money          `json:"tips,omitempty"` // 小费（单位：元，精确小数点后一位，小费金额不能高于订单金额。）
 	Info                  string         `json:"info,omitempty"`
 	CargoType             int            `json:"cargo_type,omitempty"`
 	CargoNum              int            `json:"cargo_num,omitempty"`
@@ -116,24 +108,16 @@ type DeliverFeeQueryRequest struct {
 }
 
 // DeliverFeeQueryResponse is the response of deliver_fee/query.
-type DeliverFeeQueryResponse struct {
-	Status    string                 `json:"status"`
-	Result    *DeliverFeeQueryResult `json:"result"`
-	Code      int                    `json:"code"`
-	Msg       string                 `json:"msg"`
-	Success   bool                   `json:"success"`
-	Fail      bool                   `json:"fail"`
-	ErrorCode int                    `json:"errorCode"`
-}
+type DeliverFeeQueryResponse = Response[*DeliverFeeQueryResult]
 
 // DeliverFeeQueryResult is the result of deliver_fee/query.
 type DeliverFeeQueryResult struct {
 	Distance     float64 `json:"distance"`
-	Fee          float64 `json:"fee"`
-	DeliverFee   float64 `json:"deliverFee"`
+	Fee          Money   `json:"fee"`
+	DeliverFee   Money   `json:"deliverFee"`
 	DeliveryNo   string  `json:"deliveryNo"`
-	InsuranceFee float64 `json:"insuranceFee"`
-	Tips         float64 `json:"tips"`
+	InsuranceFee Money   `json:"insuranceFee"`
+	Tips         Money   `json:"tips"`
 	ExpiredTime  int     `json:"expiredTime"`
 }
 
@@ -144,50 +128,34 @@ type OrdersCreateByDeliverFeeQueryRequest struct {
 }
 
 // OrdersCreateByDeliverFeeQueryResponse is the response of orders/create_by_deliver_fee/query.
-type OrdersCreateByDeliverFeeQueryResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersCreateByDeliverFeeQueryResponse = Response[any]
 
 // OrdersAddTipRequest is the request of orders/add_tip.
 // See: http://newopen.imdada.cn/#/development/file/addTip
 type OrdersAddTipRequest struct {
-	OrderID string  `json:"order_id"`
-	Tips    float64 `json:"tips"`
-	Info    string  `json:"info,omitempty"`
+	OrderID string `json:"order_id"`
+	Tips    Money  `json:"tips"`
+	Info    string `json:"info,omitempty"`
 }
 
 // OrdersAddTipResponse is the response of orders/add_tip.
-type OrdersAddTipResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersAddTipResponse = Response[any]
 
 // OrdersAsyncResponse is the response of async request.
 // See: http://newopen.imdada.cn/#/development/file/order
 type OrdersAsyncResponse struct {
-	ClientID         string `json:"client_id"`          // 达达物流订单号，默认为空
-	OrderID          string `json:"order_id"`           // 第三方订单ID，对应下单接口中的origin_id
-	OrderStatus      int    `json:"order_status"`       // 订单状态(待接单＝1,待取货＝2,配送中＝3,已完成＝4,已取消＝5, 已追加待接单=8,妥投异常之物品返回中=9, 妥投异常之物品返回完成=10, 骑士到店=100,创建达达运单失败=1000）
-	RepeatReasonType int    `json:"repeat_reason_type"` // 重复回传状态原因(1-重新分配骑士，2-骑士转单)。重复的状态消息默认不回传，如系统支持可在开发助手-应用信息中开启【运单重抛回调通知】开关
-	CancelReason     string `json:"cancel_reason"`      // 订单取消原因,其他状态下默认值为空字符串
-	CancelFrom       int    `json:"cancel_from"`        // 订单取消原因来源(1:达达配送员取消；2:商家主动取消；3:系统或客服取消；0:默认值)
-	UpdateTime       int64  `json:"update_time"`        // 更新时间，时间戳除了创建达达运单失败=1000的精确毫秒，其他时间戳精确到秒
-	Signature        string `json:"signature"`          // 对client_id, order_id, update_time的值进行字符串升序排列，再连接字符串，取md5值
-	DmID             int64  `json:"dm_id"`              // 达达配送员id，接单以后会传
-	DmName           string `json:"dm_name"`            // 达达配送员姓名，接单以后会传
-	DmMobile         string `json:"dm_mobile"`          // 达达配送员手机号，接单以后会传
-	FinishCode       string `json:"finish_code"`        // 收货码
+	ClientID         string      `json:"client_id"`          // 达达物流订单号，默认为空
+	OrderID          string      `json:"order_id"`           // 第三方订单ID，对应下单接口中的origin_id
+	OrderStatus      OrderStatus `json:"order_status"`       // 订单状态(待接单＝1,待取货＝2,配送中＝3,已完成＝4,已取消＝5, 已追加待接单=8,妥投异常之物品返回中=9, 妥投异常之物品返回完成=10, 骑士到店=100,创建达达运单失败=1000）
+	RepeatReasonType int         `json:"repeat_reason_type"` // 重复回传状态原因(1-重新分配骑士，2-骑士转单)。重复的状态消息默认不回传，如系统支持可在开发助手-应用信息中开启【运单重抛回调通知】开关
+	CancelReason     string      `json:"cancel_reason"`      // 订单取消原因,其他状态下默认值为空字符串
+	CancelFrom       int         `json:"cancel_from"`        // 订单取消原因来源(1:达达配送员取消；2:商家主动取消；3:系统或客服取消；0:默认值)
+	UpdateTime       int64       `json:"update_time"`        // 更新时间，时间戳除了创建达达运单失败=1000的精确毫秒，其他时间戳精确到秒
+	Signature        string      `json:"signature"`          // 对client_id, order_id, update_time的值进行字符串升序排列，再连接字符串，取md5值
+	DmID             int64       `json:"dm_id"`              // 达达配送员id，接单以后会传
+	DmName           string      `json:"dm_name"`            // 达达配送员姓名，接单以后会传
+	DmMobile         string      `json:"dm_mobile"`          // 达达配送员手机号，接单以后会传
+	FinishCode       string      `json:"finish_code"`        // 收货码
 }
 
 // OrdersQueryRequest is the request of orders/query.
@@ -197,77 +165,52 @@ type OrdersQueryRequest struct {
 }
 
 // OrdersQueryResponse is the response of orders/query.
-type OrdersQueryResponse struct {
-	Status    string             `json:"status"`
-	Result    *OrdersQueryResult `json:"result"`
-	Code      int                `json:"code"`
-	Msg       string             `json:"msg"`
-	Success   bool               `json:"success"`
-	Fail      bool               `json:"fail"`
-	ErrorCode int                `json:"errorCode"`
-}
+type OrdersQueryResponse = Response[*OrdersQueryResult]
 
 // OrdersQueryResult is the result of orders/query.
 type OrdersQueryResult struct {
-	OrderID          string  `json:"orderId"`
-	StatusCode       int     `json:"statusCode"`
-	StatusMsg        string  `json:"statusMsg"`
-	TransporterID    int     `json:"transporterId"`
-	TransporterName  string  `json:"transporterName"`
-	TransporterPhone string  `json:"transporterPhone"`
-	TransporterLng   string  `json:"transporterLng"`
-	TransporterLat   string  `json:"transporterLat"`
-	DeliveryFee      float64 `json:"deliveryFee"`
-	Tips             float64 `json:"tips"`
-	Distance         int     `json:"distance"`
-	CreateTime       string  `json:"createTime"`
-	AcceptTime       string  `json:"acceptTime"`
-	FetchTime        string  `json:"fetchTime"`
-	FinishTime       string  `json:"finishTime"`
-	CancelTime       string  `json:"cancelTime"`
-	OrderFinishCode  string  `json:"orderFinishCode"`
-	ActualFee        float64 `json:"actualFee"`
-	InsuranceFee     float64 `json:"insuranceFee"`
-	SupplierName     string  `json:"supplierName"`
-	SupplierAddress  string  `json:"supplierAddress"`
-	SupplierPhone    string  `json:"supplierPhone"`
-	SupplierLat      string  `json:"supplierLat"`
-	SupplierLng      string  `json:"supplierLng"`
-	DeductFee        int     `json:"deductFee"`
+	OrderID          string      `json:"orderId"`
+	StatusCode       OrderStatus `json:"statusCode"`
+	StatusMsg        string      `json:"statusMsg"`
+	TransporterID    int         `json:"transporterId"`
+	TransporterName  string      `json:"transporterName"`
+	TransporterPhone string      `json:"transporterPhone"`
+	TransporterLng   string      `json:"transporterLng"`
+	TransporterLat   string      `json:"transporterLat"`
+	DeliveryFee      Money       `json:"deliveryFee"`
+	Tips             Money       `json:"tips"`
+	Distance         int         `json:"distance"`
+	CreateTime       string      `json:"createTime"`
+	AcceptTime       string      `json:"acceptTime"`
+	FetchTime        string      `json:"fetchTime"`
+	FinishTime       string      `json:"finishTime"`
+	CancelTime       string      `json:"cancelTime"`
+	OrderFinishCode  string      `json:"orderFinishCode"`
+	ActualFee        Money       `json:"actualFee"`
+	InsuranceFee     Money       `json:"insuranceFee"`
+	SupplierName     string      `json:"supplierName"`
+	SupplierAddress  string      `json:"supplierAddress"`
+	SupplierPhone    string      `json:"supplierPhone"`
+	SupplierLat      string      `json:"supplierLat"`
+	SupplierLng      string      `json:"supplierLng"`
+	DeductFee        Money       `json:"deductFee"`
 }
 
 // OrdersCancelRequest is the request of orders/cancel.
 // See: http://newopen.imdada.cn/#/development/file/formalCancel
-// 1	没有配送员接单
-// 2	配送员没来取货
-// 3	配送员态度太差
-// 4	顾客取消订单
-// 5	订单填写错误
-// 34	配送员让我取消此单
-// 35	配送员不愿上门取货
-// 36	我不需要配送了
-// 37	配送员以各种理由表示无法完成订单
-// 10000	其他
+// CancelReason is required when CancelReasonID is CancelReasonOther.
 type OrdersCancelRequest struct {
-	OrderID        string `json:"order_id"`
-	CancelReasonID int    `json:"cancel_reason_id"`
-	CancelReason   string `json:"cancel_reason"`
+	OrderID        string       `json:"order_id"`
+	CancelReasonID CancelReason `json:"cancel_reason_id"`
+	CancelReason   string       `json:"cancel_reason"`
 }
 
 // OrdersCancelResponse is the response of orders/cancel.
-type OrdersCancelResponse struct {
-	Status    string              `json:"status"`
-	Result    *OrdersCancelResult `json:"result"`
-	Code      int                 `json:"code"`
-	Msg       string              `json:"msg"`
-	Success   bool                `json:"success"`
-	Fail      bool                `json:"fail"`
-	ErrorCode int                 `json:"errorCode"`
-}
+type OrdersCancelResponse = Response[*OrdersCancelResult]
 
 // OrdersCancelResult is the result of orders/cancel.
 type OrdersCancelResult struct {
-	DeductFee float64 `json:"deduct_fee"`
+	DeductFee Money `json:"deduct_fee"`
 }
 
 // OrdersAddAppointRequest is the request of orders/addAppoint.
@@ -279,15 +222,7 @@ type OrdersAddAppointRequest struct {
 }
 
 // OrdersAddAppointResponse is the response of orders/addAppoint.
-type OrdersAddAppointResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersAddAppointResponse = Response[any]
 
 // OrdersCancelAppointRequest is the request of orders/cancelAppoint.
 // See: http://newopen.imdada.cn/#/development/file/appointOrderCancel
@@ -296,15 +231,7 @@ type OrdersCancelAppointRequest struct {
 }
 
 // OrdersCancelAppointResponse is the response of orders/cancelAppoint.
-type OrdersCancelAppointResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersCancelAppointResponse = Response[any]
 
 // OrdersAppointTransporterRequest is the request of orders/appointTransporter.
 // See: http://newopen.imdada.cn/#/development/file/listTransportersToAppoint
@@ -313,15 +240,7 @@ type OrdersAppointTransporterRequest struct {
 }
 
 // OrdersAppointTransporterResponse is the response of orders/appointTransporter.
-type OrdersAppointTransporterResponse struct {
-	Status    string                   `json:"status"`
-	Result    []*OrdersTransporterItem `json:"result"`
-	Code      int                      `json:"code"`
-	Msg       string                   `json:"msg"`
-	Success   bool                     `json:"success"`
-	Fail      bool                     `json:"fail"`
-	ErrorCode int                      `json:"errorCode"`
-}
+type OrdersAppointTransporterResponse = Response[[]*OrdersTransporterItem]
 
 // OrdersTransporterItem is the item of orders/appointTransporter.
 type OrdersTransporterItem struct {
@@ -339,15 +258,7 @@ type ComplaintRequest struct {
 }
 
 // ComplaintResponse is the response of complaint.
-type ComplaintResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type ComplaintResponse = Response[any]
 
 // ComplaintReasonRequest is the request of complaint/reason.
 // See: http://newopen.imdada.cn/#/development/file/complaintReasons
@@ -356,15 +267,7 @@ type ComplaintReasonRequest struct {
 }
 
 // ComplaintReasonResponse is the response of complaint/reason.
-type ComplaintReasonResponse struct {
-	Status    string                   `json:"status"`
-	Result    []*ComplaintReasonResult `json:"result"`
-	Code      int                      `json:"code"`
-	Msg       string                   `json:"msg"`
-	Success   bool                     `json:"success"`
-	Fail      bool                     `json:"fail"`
-	ErrorCode int                      `json:"errorCode"`
-}
+type ComplaintReasonResponse = Response[[]*ComplaintReasonResult]
 
 // ComplaintReasonResult is the result of complaint/reason.
 type ComplaintReasonResult struct {
@@ -380,15 +283,7 @@ type OrdersConfirmGoodsRequest struct {
 }
 
 // OrdersConfirmGoodsResponse is the response of orders/confirmGoods.
-type OrdersConfirmGoodsResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersConfirmGoodsResponse = Response[any]
 
 // OrdersTransporterCancelAsyncRequest is the request of orders/transporterCancel.
 // See: http://newopen.imdada.cn/#/development/file/applicationCancel
@@ -424,21 +319,13 @@ type OrdersTransporterCancelAsyncConfirmRequest struct {
 type MessageBodyConfirm struct {
 	OrderID      string   `json:"orderId"`                // 商家第三方订单号
 	DadaOrderID  int64    `json:"dadaOrderId"`            // 达达订单号
-	IsConfirm    int      `json:"cancelReason"`           // 0:不同意，1:表示同意
+	IsConfirm    int      `json:"isConfirm"`              // 0:不同意，1:表示同意
 	Imgs         []string `json:"imgs,omitempty"`         // 审核不通过的图片列表
 	RejectReason string   `json:"rejectReason,omitempty"` // 拒绝原因
 }
 
 // OrdersTransporterCancelAsyncConfirmResponse is the response of orders/transporterCancel.
-type OrdersTransporterCancelAsyncConfirmResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersTransporterCancelAsyncConfirmResponse = Response[any]
 
 // OrdersTransporterPositionRequest is the request of orders/transporterPosition.
 // See: http://newopen.imdada.cn/#/development/file/queryLocation 查询骑士位置
@@ -448,15 +335,7 @@ type OrdersTransporterPositionRequest struct {
 }
 
 // OrdersTransporterPositionResponse is the response of orders/transporterPosition.
-type OrdersTransporterPositionResponse struct {
-	Status    string               `json:"status"`
-	Result    []*OuterPositionInfo `json:"result"`
-	Code      int                  `json:"code"`
-	Msg       string               `json:"msg"`
-	Success   bool                 `json:"success"`
-	Fail      bool                 `json:"fail"`
-	ErrorCode int                  `json:"errorCode"`
-}
+type OrdersTransporterPositionResponse = Response[[]*OuterPositionInfo]
 
 // OuterPositionInfo is the info of orders/transporterPosition.
 type OuterPositionInfo struct {
@@ -475,15 +354,7 @@ type OrdersTransporterTrackRequest struct {
 }
 
 // OrdersTransporterTrackResponse is the response of orders/transporterTrack.
-type OrdersTransporterTrackResponse struct {
-	Status    string                       `json:"status"`
-	Result    OrdersTransporterTrackResult `json:"result"`
-	Code      int                          `json:"code"`
-	Msg       string                       `json:"msg"`
-	Success   bool                         `json:"success"`
-	Fail      bool                         `json:"fail"`
-	ErrorCode int                          `json:"errorCode"`
-}
+type OrdersTransporterTrackResponse = Response[OrdersTransporterTrackResult]
 
 // OrdersTransporterTrackResult is the result of orders/transporterTrack.
 type OrdersTransporterTrackResult struct {
@@ -501,12 +372,4 @@ type OrdersFetchCodeModifyRequest struct {
 }
 
 // OrdersFetchCodeModifyResponse is the response of orders/fetchCodeModify.
-type OrdersFetchCodeModifyResponse struct {
-	Status    string      `json:"status"`
-	Result    interface{} `json:"result"`
-	Code      int         `json:"code"`
-	Msg       string      `json:"msg"`
-	Success   bool        `json:"success"`
-	Fail      bool        `json:"fail"`
-	ErrorCode int         `json:"errorCode"`
-}
+type OrdersFetchCodeModifyResponse = Response[any]
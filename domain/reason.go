@@ -0,0 +1,88 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CancelReason is the id of an order cancel reason.
+// The live list can be queried with /api/order/cancel/reasons.
+type CancelReason int
+
+// Cancel reason.
+const (
+	CancelReasonNoTransporter        CancelReason = 1     // 没有配送员接单
+	CancelReasonNoPickup             CancelReason = 2     // 配送员没来取货
+	CancelReasonBadAttitude          CancelReason = 3     // 配送员态度太差
+	CancelReasonCustomerCancelled    CancelReason = 4     // 顾客取消订单
+	CancelReasonWrongOrder           CancelReason = 5     // 订单填写错误
+	CancelReasonTransporterAsked     CancelReason = 34    // 配送员让我取消此单
+	CancelReasonTransporterNoPickup  CancelReason = 35    // 配送员不愿上门取货
+	CancelReasonNotNeeded            CancelReason = 36    // 我不需要配送了
+	CancelReasonTransporterCantServe CancelReason = 37    // 配送员以各种理由表示无法完成订单
+	CancelReasonOther                CancelReason = 10000 // 其他
+)
+
+var cancelReasonNames = map[CancelReason]string{
+	CancelReasonNoTransporter:        "没有配送员接单",
+	CancelReasonNoPickup:             "配送员没来取货",
+	CancelReasonBadAttitude:          "配送员态度太差",
+	CancelReasonCustomerCancelled:    "顾客取消订单",
+	CancelReasonWrongOrder:           "订单填写错误",
+	CancelReasonTransporterAsked:     "配送员让我取消此单",
+	CancelReasonTransporterNoPickup:  "配送员不愿上门取货",
+	CancelReasonNotNeeded:            "我不需要配送了",
+	CancelReasonTransporterCantServe: "配送员以各种理由表示无法完成订单",
+	CancelReasonOther:                "其他",
+}
+
+// String returns the description of the reason.
+func (r CancelReason) String() string {
+	if name, ok := cancelReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("CancelReason(%d)", int(r))
+}
+
+// ErrCancelReasonRequired is returned when the reason is CancelReasonOther without a description.
+var ErrCancelReasonRequired = errors.New("domain: cancel_reason is required when cancel_reason_id is 10000")
+
+// Validate checks the request before it is sent.
+func (r *OrdersCancelRequest) Validate() error {
+	if r.CancelReasonID == CancelReasonOther && r.CancelReason == "" {
+		return ErrCancelReasonRequired
+	}
+	return nil
+}
+
+// CancelReasonsRequest is the request of order/cancel/reasons.
+// See: http://newopen.imdada.cn/#/development/file/reasonList
+type CancelReasonsRequest struct {
+}
+
+// CancelReasonsResponse is the response of order/cancel/reasons.
+type CancelReasonsResponse = Response[[]*CancelReasonItem]
+
+// CancelReasonItem is the item of order/cancel/reasons.
+type CancelReasonItem struct {
+	ID     CancelReason `json:"id"`
+	Reason string       `json:"reason"`
+}
@@ -20,25 +20,31 @@
 // See: http://newopen.imdada.cn/#/development/file/rechargeIndex
 package domain
 
+import (
+	"github.com/bytedance/sonic"
+)
+
 // RechargeRequest is the request of recharge.
 // See: http://newopen.imdada.cn/#/development/file/recharge
 type RechargeRequest struct {
-	Amount    float64 `json:"amount,string"`        // 充值金额（单位元，可以精确到分）
-	Category  string  `json:"category"`             // 生成链接适应场景（category有二种类型值：PC、H5）
-	NotifyURL string  `json:"notify_url,omitempty"` // 支付成功后跳转的页面（支付宝在支付成功后可以跳转到某个指定的页面，微信支付不支持）
-	ShopNo    string  `json:"shop_no,omitempty"`    // 门店编号。如需要为商户账号下独立结算子门店充值，则需要传入(充值到大客户账户则不传)，如门店非独立结算则返回错误
+	Amount    Money  `json:"amount"`               // 充值金额（单位元，可以精确到分），以字符串传输
+	Category  string `json:"category"`             // 生成链接适应场景（category有二种类型值：PC、H5）
+	NotifyURL string `json:"notify_url,omitempty"` // 支付成功后跳转的页面（支付宝在支付成功后可以跳转到某个指定的页面，微信支付不支持）
+	ShopNo    string `json:"shop_no,omitempty"`    // 门店编号。如需要为商户账号下独立结算子门店充值，则需要传入(充值到大客户账户则不传)，如门店非独立结算则返回错误
+}
+
+// MarshalJSON encodes Amount as a string in yuan, e.g. "12.34".
+func (r RechargeRequest) MarshalJSON() ([]byte, error) {
+	type request RechargeRequest
+	return sonic.Marshal(&struct {
+		request
+		Amount string `json:"amount"`
+	}{request(r), r.Amount.String()})
 }
 
 // RechargeResponse is the response of recharge.
 // See: http://newopen.imdada.cn/#/development/file/recharge
-type RechargeResponse struct {
-	Status  string `json:"status"`
-	Result  string `json:"result"`
-	Code    int    `json:"code"`
-	Msg     string `json:"msg"`
-	Success bool   `json:"success"`
-	Fail    bool   `json:"fail"`
-}
+type RechargeResponse = Response[string]
 
 // QueryBalanceRequest is the request of QueryBalance.
 // See: http://newopen.imdada.cn/#/development/file/balanceQuery
@@ -51,17 +57,10 @@ type QueryBalanceRequest struct {
 // See: http://newopen.imdada.cn/#/development/file/balanceQuery
 // 运费账户或红包账户的余额。如未传入门店编号字段，则返回大客户账户余额，
 // 如传入门店编号且为独立结算则返回子门店账户余额，如门店非独立结算则返回0
-type QueryBalanceResponse struct {
-	Result  *BalanceResult `json:"result"`
-	Status  string         `json:"status"`
-	Msg     string         `json:"msg"`
-	Code    int            `json:"code"`
-	Success bool           `json:"success"`
-	Fail    bool           `json:"fail"`
-}
+type QueryBalanceResponse = Response[*BalanceResult]
 
 // BalanceResult is the result of QueryBalance.
 type BalanceResult struct {
-	RedPacketBalance float64 `json:"redPacketBalance"`
-	DeliverBalance   float64 `json:"deliverBalance"`
+	RedPacketBalance Money `json:"redPacketBalance"`
+	DeliverBalance   Money `json:"deliverBalance"`
 }
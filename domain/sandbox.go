@@ -0,0 +1,36 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+// Package domain is the domain of ImDaDa.
+// See: http://newopen.imdada.cn/#/development/file/orderIndex
+package domain
+
+// SandboxOrderRequest is the request of the simulation APIs of the test environment.
+// 仅在测试环境可用，order_id 为第三方订单号
+type SandboxOrderRequest struct {
+	OrderID string `json:"order_id"`
+}
+
+// SandboxOrderCancelRequest is the request of order/cancel of the test environment.
+type SandboxOrderCancelRequest struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason,omitempty"` // 取消原因
+}
+
+// SandboxOrderResponse is the response of the simulation APIs of the test environment.
+type SandboxOrderResponse = Response[any]
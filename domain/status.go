@@ -0,0 +1,183 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"fmt"
+)
+
+// OrderStatus is the status of an order.
+type OrderStatus int
+
+// Order status.
+const (
+	OrderStatusWaitAccept   OrderStatus = 1    // 待接单
+	OrderStatusWaitFetch    OrderStatus = 2    // 待取货
+	OrderStatusDelivering   OrderStatus = 3    // 配送中
+	OrderStatusFinished     OrderStatus = 4    // 已完成
+	OrderStatusCancelled    OrderStatus = 5    // 已取消
+	OrderStatusExpired      OrderStatus = 7    // 已过期
+	OrderStatusAppointed    OrderStatus = 8    // 指派单
+	OrderStatusReturning    OrderStatus = 9    // 妥投异常之物品返回中
+	OrderStatusReturned     OrderStatus = 10   // 妥投异常之物品返回完成
+	OrderStatusArrived      OrderStatus = 100  // 骑士到店
+	OrderStatusCreateFailed OrderStatus = 1000 // 创建达达运单失败
+)
+
+var orderStatusNames = map[OrderStatus]string{
+	OrderStatusWaitAccept:   "待接单",
+	OrderStatusWaitFetch:    "待取货",
+	OrderStatusDelivering:   "配送中",
+	OrderStatusFinished:     "已完成",
+	OrderStatusCancelled:    "已取消",
+	OrderStatusExpired:      "已过期",
+	OrderStatusAppointed:    "指派单",
+	OrderStatusReturning:    "妥投异常之物品返回中",
+	OrderStatusReturned:     "妥投异常之物品返回完成",
+	OrderStatusArrived:      "骑士到店",
+	OrderStatusCreateFailed: "创建达达运单失败",
+}
+
+// String returns the name of the status.
+func (s OrderStatus) String() string {
+	if name, ok := orderStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("OrderStatus(%d)", int(s))
+}
+
+// Known reports whether the status is a known status.
+func (s OrderStatus) Known() bool {
+	_, ok := orderStatusNames[s]
+	return ok
+}
+
+// Terminal reports whether the order can not change any more.
+func (s OrderStatus) Terminal() bool {
+	switch s {
+	case OrderStatusFinished, OrderStatusCancelled, OrderStatusExpired, OrderStatusReturned, OrderStatusCreateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Active reports whether the order is being dispatched or delivered.
+func (s OrderStatus) Active() bool {
+	return s.Known() && !s.Terminal()
+}
+
+// Transitions is a transition table of the order status, it maps a status to the next ones.
+type Transitions map[OrderStatus][]OrderStatus
+
+// Repeat reason of a callback, see OrdersAsyncResponse.RepeatReasonType.
+const (
+	RepeatReasonReassign = 1 // 重新分配骑士
+	RepeatReasonTransfer = 2 // 骑士转单
+)
+
+// OrderTransitions is the transition table of the gateway.
+// It has no edge going back, a rider reassignment is accepted by ValidateCallback only.
+var OrderTransitions = Transitions{
+	OrderStatusWaitAccept: {OrderStatusWaitFetch, OrderStatusAppointed, OrderStatusCancelled, OrderStatusExpired},
+	OrderStatusAppointed:  {OrderStatusWaitFetch, OrderStatusCancelled, OrderStatusExpired},
+	OrderStatusWaitFetch:  {OrderStatusArrived, OrderStatusDelivering, OrderStatusCancelled},
+	OrderStatusArrived:    {OrderStatusDelivering, OrderStatusCancelled},
+	OrderStatusDelivering: {OrderStatusFinished, OrderStatusReturning, OrderStatusCancelled},
+	OrderStatusReturning:  {OrderStatusReturned},
+}
+
+// TransitionError is the error of an impossible transition.
+type TransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invalid order status transition: %s(%d) -> %s(%d)", e.From, int(e.From), e.To, int(e.To))
+}
+
+// Next returns the statuses following from directly.
+func (t Transitions) Next(from OrderStatus) []OrderStatus {
+	return t[from]
+}
+
+// Reachable reports whether to can follow from, directly or through missed statuses.
+func (t Transitions) Reachable(from, to OrderStatus) bool {
+	seen := map[OrderStatus]bool{from: true}
+	queue := []OrderStatus{from}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, next := range t[s] {
+			if next == to {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// Validate returns a *TransitionError when the order can not go from from to to.
+// Callbacks arrive out of order, so a status skipping missed ones is valid while a status
+// going back, e.g. 已完成 -> 配送中, is not. A repeated status and a zero from are valid.
+func (t Transitions) Validate(from, to OrderStatus) error {
+	if !to.Known() {
+		return &TransitionError{From: from, To: to}
+	}
+	if from == 0 || from == to || t.Reachable(from, to) {
+		return nil
+	}
+	return &TransitionError{From: from, To: to}
+}
+
+// ValidateCallback validates the status of a callback following from. A callback repeated because
+// the rider was reassigned or transferred the order (RepeatReasonType set) may send an order
+// waiting for or at the shop back to 待接单 or 待取货.
+func (t Transitions) ValidateCallback(from OrderStatus, notify *OrdersAsyncResponse) error {
+	if notify.RepeatReasonType != 0 && reassignable(from, notify.OrderStatus) {
+		return nil
+	}
+	return t.Validate(from, notify.OrderStatus)
+}
+
+// reassignable reports whether a rider reassignment can send the order from from back to to.
+func reassignable(from, to OrderStatus) bool {
+	switch from {
+	case OrderStatusAppointed, OrderStatusWaitFetch, OrderStatusArrived:
+		return to == OrderStatusWaitAccept || to == OrderStatusWaitFetch
+	default:
+		return false
+	}
+}
+
+// ValidateTransition validates the transition with OrderTransitions.
+func ValidateTransition(from, to OrderStatus) error {
+	return OrderTransitions.Validate(from, to)
+}
+
+// ValidateCallback validates the status of a callback with OrderTransitions.
+func ValidateCallback(from OrderStatus, notify *OrdersAsyncResponse) error {
+	return OrderTransitions.ValidateCallback(from, notify)
+}
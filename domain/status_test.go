@@ -0,0 +1,107 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to domain.OrderStatus
+		valid    bool
+	}{
+		{0, domain.OrderStatusWaitAccept, true},
+		{domain.OrderStatusWaitAccept, domain.OrderStatusWaitAccept, true},
+		{domain.OrderStatusWaitAccept, domain.OrderStatusWaitFetch, true},
+		{domain.OrderStatusWaitAccept, domain.OrderStatusAppointed, true},
+		{domain.OrderStatusWaitAccept, domain.OrderStatusFinished, true}, // missed 待取货 and 配送中
+		{domain.OrderStatusAppointed, domain.OrderStatusWaitFetch, true},
+		{domain.OrderStatusWaitFetch, domain.OrderStatusArrived, true},
+		{domain.OrderStatusWaitFetch, domain.OrderStatusDelivering, true},
+		{domain.OrderStatusArrived, domain.OrderStatusDelivering, true},
+		{domain.OrderStatusDelivering, domain.OrderStatusFinished, true},
+		{domain.OrderStatusDelivering, domain.OrderStatusReturned, true},
+		{domain.OrderStatusReturning, domain.OrderStatusReturned, true},
+		{domain.OrderStatusArrived, domain.OrderStatusCancelled, true},
+
+		// Going back needs a reassignment, see TestValidateCallback.
+		{domain.OrderStatusWaitFetch, domain.OrderStatusWaitAccept, false},
+		{domain.OrderStatusArrived, domain.OrderStatusWaitAccept, false},
+		{domain.OrderStatusArrived, domain.OrderStatusWaitFetch, false},
+		{domain.OrderStatusAppointed, domain.OrderStatusWaitAccept, false},
+		{domain.OrderStatusDelivering, domain.OrderStatusWaitFetch, false},
+		{domain.OrderStatusFinished, domain.OrderStatusDelivering, false},
+		{domain.OrderStatusCancelled, domain.OrderStatusWaitAccept, false},
+		{domain.OrderStatusExpired, domain.OrderStatusWaitFetch, false},
+		{domain.OrderStatusReturned, domain.OrderStatusReturning, false},
+		{domain.OrderStatusCreateFailed, domain.OrderStatusWaitAccept, false},
+		{domain.OrderStatusReturning, domain.OrderStatusFinished, false},
+		{domain.OrderStatusWaitAccept, 6, false},
+		{0, 6, false},
+	}
+	for _, tt := range tests {
+		err := domain.ValidateTransition(tt.from, tt.to)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateTransition(%d, %d) = %v, want valid %t", tt.from, tt.to, err, tt.valid)
+		}
+		var te *domain.TransitionError
+		if err != nil && (!errors.As(err, &te) || te.From != tt.from || te.To != tt.to) {
+			t.Errorf("ValidateTransition(%d, %d) = %#v, want a *TransitionError", tt.from, tt.to, err)
+		}
+	}
+}
+
+func TestTransitionsAcyclic(t *testing.T) {
+	for from := range domain.OrderTransitions {
+		if domain.OrderTransitions.Reachable(from, from) {
+			t.Errorf("%s(%d) can reach itself", from, from)
+		}
+	}
+}
+
+func TestValidateCallback(t *testing.T) {
+	tests := []struct {
+		from   domain.OrderStatus
+		to     domain.OrderStatus
+		repeat int
+		valid  bool
+	}{
+		{domain.OrderStatusWaitFetch, domain.OrderStatusWaitAccept, domain.RepeatReasonReassign, true},
+		{domain.OrderStatusArrived, domain.OrderStatusWaitAccept, domain.RepeatReasonReassign, true},
+		{domain.OrderStatusAppointed, domain.OrderStatusWaitAccept, domain.RepeatReasonReassign, true},
+		{domain.OrderStatusArrived, domain.OrderStatusWaitFetch, domain.RepeatReasonTransfer, true},
+		{domain.OrderStatusWaitFetch, domain.OrderStatusWaitFetch, domain.RepeatReasonTransfer, true},
+		{domain.OrderStatusWaitFetch, domain.OrderStatusWaitAccept, 0, false},
+		{domain.OrderStatusArrived, domain.OrderStatusWaitFetch, 0, false},
+		{domain.OrderStatusDelivering, domain.OrderStatusWaitAccept, domain.RepeatReasonReassign, false},
+		{domain.OrderStatusFinished, domain.OrderStatusWaitFetch, domain.RepeatReasonTransfer, false},
+		{domain.OrderStatusCancelled, domain.OrderStatusWaitAccept, domain.RepeatReasonReassign, false},
+		{domain.OrderStatusWaitAccept, domain.OrderStatusDelivering, 0, true},
+	}
+	for _, tt := range tests {
+		notify := &domain.OrdersAsyncResponse{OrderStatus: tt.to, RepeatReasonType: tt.repeat}
+		if err := domain.ValidateCallback(tt.from, notify); (err == nil) != tt.valid {
+			t.Errorf("ValidateCallback(%d, %d, repeat %d) = %v, want valid %t", tt.from, tt.to, tt.repeat, err, tt.valid)
+		}
+	}
+}
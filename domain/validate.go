@@ -0,0 +1,123 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FieldError is the error of a field failing the validation.
+type FieldError struct {
+	Field string // 字段的 json 名称
+	Value any    // 字段的值
+	Msg   string // 校验失败的原因
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Msg
+}
+
+// ValidationErrors is the errors of a request failing the validation.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return "domain: invalid request: " + strings.Join(msgs, "; ")
+}
+
+// Field returns the error of the field, or nil when the field is valid.
+func (e ValidationErrors) Field(field string) *FieldError {
+	for _, err := range e {
+		if err.Field == field {
+			return err
+		}
+	}
+	return nil
+}
+
+// add appends the error of a field.
+func (e *ValidationErrors) add(field string, value any, msg string) {
+	*e = append(*e, &FieldError{Field: field, Value: value, Msg: msg})
+}
+
+// err returns nil when there is no error, so the result compares equal to nil.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+var originMarkNoPattern = regexp.MustCompile(`^[0-9A-Za-z#]{0,30}$`)
+
+const (
+	minDelayPublish = 5 * time.Minute
+	maxDelayPublish = 3 * 24 * time.Hour
+)
+
+// Validate checks the request against the rules of the gateway, the error is a ValidationErrors.
+func (r *OrdersCreateRequest) Validate() error {
+	var errs ValidationErrors
+	if !originMarkNoPattern.MatchString(r.OriginMarkNo) {
+		errs.add("origin_mark_no", r.OriginMarkNo, "must be at most 30 digits, letters or #")
+	}
+	if r.Tips < 0 {
+		errs.add("tips", r.Tips, "must not be negative")
+	} else if r.Tips%10 != 0 {
+		errs.add("tips", r.Tips, "must have at most one decimal place")
+	} else if r.Tips > r.CargoPrice {
+		errs.add("tips", r.Tips, "must not exceed cargo_price")
+	}
+	if r.DelayPublishTime != 0 {
+		now, at := time.Now(), time.Unix(int64(r.DelayPublishTime), 0)
+		switch {
+		case r.DelayPublishTime%60 != 0:
+			errs.add("delay_publish_time", r.DelayPublishTime, "must be on a whole minute")
+		case at.Before(now.Add(minDelayPublish)):
+			errs.add("delay_publish_time", r.DelayPublishTime, "must be at least 5 minutes ahead")
+		case at.After(now.Add(maxDelayPublish)):
+			errs.add("delay_publish_time", r.DelayPublishTime, "must be within 3 days")
+		}
+	}
+	if r.ReceiverPhone == "" && r.ReceiverTel == "" {
+		errs.add("receiver_phone", r.ReceiverPhone, "receiver_phone or receiver_tel is required")
+	}
+	switch {
+	case r.ReceiverLat == 0 && r.ReceiverLng == 0:
+		errs.add("receiver_lat", r.ReceiverLat, "receiver_lat and receiver_lng are required")
+	case r.ReceiverLat < -90 || r.ReceiverLat > 90:
+		errs.add("receiver_lat", r.ReceiverLat, "must be between -90 and 90")
+	case r.ReceiverLng < -180 || r.ReceiverLng > 180:
+		errs.add("receiver_lng", r.ReceiverLng, "must be between -180 and 180")
+	}
+	return errs.err()
+}
+
+// Validate checks the request with the rules of OrdersCreateRequest.
+func (r *DeliverFeeQueryRequest) Validate() error {
+	req := OrdersCreateRequest(*r)
+	return req.Validate()
+}
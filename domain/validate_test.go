@@ -0,0 +1,123 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// validOrder returns an order passing every rule.
+func validOrder() domain.OrdersCreateRequest {
+	return domain.OrdersCreateRequest{
+		ShopNo:        "shop",
+		OriginID:      "order-1",
+		CargoPrice:    domain.Yuan(20),
+		ReceiverName:  "收件人",
+		ReceiverPhone: "13800000000",
+		ReceiverLat:   31.230416,
+		ReceiverLng:   121.473701,
+	}
+}
+
+// minuteFromNow returns the unix time on the whole minute after d.
+func minuteFromNow(d time.Duration) int {
+	return int(time.Now().Add(d).Truncate(time.Minute).Add(time.Minute).Unix())
+}
+
+func TestOrdersCreateRequestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *domain.OrdersCreateRequest)
+		field  string // 期望失败的字段，空表示通过
+	}{
+		{"valid", func(r *domain.OrdersCreateRequest) {}, ""},
+		{"origin_mark_no", func(r *domain.OrdersCreateRequest) { r.OriginMarkNo = "#M001" }, ""},
+		{"origin_mark_no 30 chars", func(r *domain.OrdersCreateRequest) { r.OriginMarkNo = strings.Repeat("a", 30) }, ""},
+		{"origin_mark_no too long", func(r *domain.OrdersCreateRequest) { r.OriginMarkNo = strings.Repeat("a", 31) }, "origin_mark_no"},
+		{"origin_mark_no invalid char", func(r *domain.OrdersCreateRequest) { r.OriginMarkNo = "M-001" }, "origin_mark_no"},
+		{"origin_mark_no chinese", func(r *domain.OrdersCreateRequest) { r.OriginMarkNo = "美团1" }, "origin_mark_no"},
+		{"tips one decimal", func(r *domain.OrdersCreateRequest) { r.Tips = domain.Yuan(1.5) }, ""},
+		{"tips equal to cargo_price", func(r *domain.OrdersCreateRequest) { r.Tips = r.CargoPrice }, ""},
+		{"tips negative", func(r *domain.OrdersCreateRequest) { r.Tips = -10 }, "tips"},
+		{"tips two decimals", func(r *domain.OrdersCreateRequest) { r.Tips = domain.Yuan(1.25) }, "tips"},
+		{"tips above cargo_price", func(r *domain.OrdersCreateRequest) { r.Tips = r.CargoPrice + 10 }, "tips"},
+		{"delay_publish_time", func(r *domain.OrdersCreateRequest) { r.DelayPublishTime = minuteFromNow(time.Hour) }, ""},
+		{"delay_publish_time not whole minute", func(r *domain.OrdersCreateRequest) { r.DelayPublishTime = minuteFromNow(time.Hour) + 30 }, "delay_publish_time"},
+		{"delay_publish_time too soon", func(r *domain.OrdersCreateRequest) { r.DelayPublishTime = minuteFromNow(time.Minute) }, "delay_publish_time"},
+		{"delay_publish_time past", func(r *domain.OrdersCreateRequest) { r.DelayPublishTime = minuteFromNow(-time.Hour) }, "delay_publish_time"},
+		{"delay_publish_time too far", func(r *domain.OrdersCreateRequest) { r.DelayPublishTime = minuteFromNow(73 * time.Hour) }, "delay_publish_time"},
+		{"receiver_tel only", func(r *domain.OrdersCreateRequest) { r.ReceiverPhone, r.ReceiverTel = "", "021-12345678" }, ""},
+		{"receiver phone missing", func(r *domain.OrdersCreateRequest) { r.ReceiverPhone = "" }, "receiver_phone"},
+		{"receiver location missing", func(r *domain.OrdersCreateRequest) { r.ReceiverLat, r.ReceiverLng = 0, 0 }, "receiver_lat"},
+		{"receiver_lat out of range", func(r *domain.OrdersCreateRequest) { r.ReceiverLat = 91 }, "receiver_lat"},
+		{"receiver_lat negative out of range", func(r *domain.OrdersCreateRequest) { r.ReceiverLat = -90.5 }, "receiver_lat"},
+		{"receiver_lng out of range", func(r *domain.OrdersCreateRequest) { r.ReceiverLng = 181 }, "receiver_lng"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validOrder()
+			tt.modify(&r)
+			err := r.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var errs domain.ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() = %#v, want ValidationErrors", err)
+			}
+			if len(errs) != 1 || errs.Field(tt.field) == nil {
+				t.Errorf("Validate() = %v, want one error of %s", err, tt.field)
+			}
+
+			fee := domain.DeliverFeeQueryRequest(r)
+			if err := fee.Validate(); err == nil || err.Error() != errs.Error() {
+				t.Errorf("DeliverFeeQueryRequest.Validate() = %v, want %v", err, errs)
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	r := validOrder()
+	r.OriginMarkNo, r.Tips, r.ReceiverPhone = "M-1", -10, ""
+	err := r.Validate()
+	var errs domain.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("Validate() = %v, want 3 errors", err)
+	}
+	if fe := errs.Field("tips"); fe == nil || fe.Value != domain.Money(-10) {
+		t.Errorf("Field(tips) = %+v", fe)
+	}
+	if fe := errs.Field("receiver_lat"); fe != nil {
+		t.Errorf("Field(receiver_lat) = %+v, want nil", fe)
+	}
+	want := "domain: invalid request: origin_mark_no: must be at most 30 digits, letters or #; " +
+		"tips: must not be negative; receiver_phone: receiver_phone or receiver_tel is required"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// APIError is returned when the gateway answers a request with a failed status.
+// Use errors.As to get the detail of the failure.
+type APIError struct {
+	StatusCode int    // http 状态码
+	Status     string // 响应状态，成功为"success"，失败为"fail"
+	Code       int    // 响应返回码
+	ErrorCode  int    // 错误编码
+	Msg        string // 响应描述
+	Path       string // 接口路径
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("dadago: %s failed, status: %s, code: %d, errorCode: %d, msg: %s", e.Path, e.Status, e.Code, e.ErrorCode, e.Msg)
+}
+
+// HTTPError is returned when the gateway answers with a non-200 http status.
+type HTTPError struct {
+	StatusCode int    // http 状态码
+	Path       string // 接口路径
+	Body       []byte // 响应内容
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("dadago: %s failed, http status: %d, body: %s", e.Path, e.StatusCode, e.Body)
+}
+
+// DecodeError is returned when the response body of the gateway is not valid JSON.
+type DecodeError struct {
+	Path string // 接口路径
+	Body []byte // 响应内容
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("dadago: %s decode response failed: %v, body: %s", e.Path, e.Err, e.Body)
+}
+
+// Unwrap returns the underlying decode error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// checkResponse checks the raw response of the gateway and returns a typed error on failure.
+func checkResponse(path string, statusCode int, body []byte) error {
+	if statusCode != http.StatusOK {
+		return &HTTPError{StatusCode: statusCode, Path: path, Body: body}
+	}
+	var meta domain.Response[sonic.NoCopyRawMessage]
+	if err := sonic.Unmarshal(body, &meta); err != nil {
+		return &DecodeError{Path: path, Body: body, Err: err}
+	}
+	if !meta.OK() {
+		return &APIError{
+			StatusCode: statusCode,
+			Status:     meta.Status,
+			Code:       meta.Code,
+			ErrorCode:  meta.ErrorCode,
+			Msg:        meta.Msg,
+			Path:       path,
+		}
+	}
+	return nil
+}
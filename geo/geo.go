@@ -0,0 +1,219 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+// Package geo converts coordinates between WGS-84, GCJ-02 and BD-09.
+// The gateway uses GCJ-02 (高德/腾讯坐标), GPS reports WGS-84 and Baidu maps use BD-09.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Datum is the coordinate system of a point.
+type Datum int
+
+// Coordinate system.
+const (
+	WGS84 Datum = iota + 1 // GPS 坐标
+	GCJ02                  // 国测局坐标，高德、腾讯地图，达达使用此坐标系
+	BD09                   // 百度坐标
+)
+
+// String returns the name of the datum.
+func (d Datum) String() string {
+	switch d {
+	case WGS84:
+		return "WGS-84"
+	case GCJ02:
+		return "GCJ-02"
+	case BD09:
+		return "BD-09"
+	default:
+		return fmt.Sprintf("Datum(%d)", int(d))
+	}
+}
+
+var (
+	// ErrUnknownDatum is returned for a point without a known datum.
+	ErrUnknownDatum = errors.New("geo: unknown datum")
+	// ErrOutOfRange is returned for a latitude or longitude out of range.
+	ErrOutOfRange = errors.New("geo: coordinate out of range")
+)
+
+// Point is a coordinate tagged with its datum.
+type Point struct {
+	Lat   float64 // 纬度
+	Lng   float64 // 经度
+	Datum Datum
+}
+
+// New returns the point of lat and lng in the datum.
+func New(lat, lng float64, datum Datum) Point {
+	return Point{Lat: lat, Lng: lng, Datum: datum}
+}
+
+// Parse parses the point of the decimal strings lat and lng in the datum.
+func Parse(lat, lng string, datum Datum) (Point, error) {
+	la, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("geo: invalid latitude %q: %w", lat, err)
+	}
+	ln, err := strconv.ParseFloat(lng, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("geo: invalid longitude %q: %w", lng, err)
+	}
+	p := New(la, ln, datum)
+	return p, p.Validate()
+}
+
+// Validate checks the datum and the range of the coordinate.
+func (p Point) Validate() error {
+	switch p.Datum {
+	case WGS84, GCJ02, BD09:
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownDatum, int(p.Datum))
+	}
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("%w: latitude %v", ErrOutOfRange, p.Lat)
+	}
+	if math.IsNaN(p.Lng) || p.Lng < -180 || p.Lng > 180 {
+		return fmt.Errorf("%w: longitude %v", ErrOutOfRange, p.Lng)
+	}
+	return nil
+}
+
+// String returns the point as "lat,lng(datum)".
+func (p Point) String() string {
+	return fmt.Sprintf("%v,%v(%s)", p.Lat, p.Lng, p.Datum)
+}
+
+// To converts the point to the datum.
+// Points outside China are the same in WGS-84 and GCJ-02.
+func (p Point) To(datum Datum) (Point, error) {
+	if err := p.Validate(); err != nil {
+		return Point{}, err
+	}
+	if p.Datum == datum {
+		return p, nil
+	}
+	// Convert through GCJ-02.
+	lat, lng := p.Lat, p.Lng
+	switch p.Datum {
+	case WGS84:
+		lat, lng = wgs84ToGCJ02(lat, lng)
+	case BD09:
+		lat, lng = bd09ToGCJ02(lat, lng)
+	}
+	switch datum {
+	case WGS84:
+		lat, lng = gcj02ToWGS84(lat, lng)
+	case BD09:
+		lat, lng = gcj02ToBD09(lat, lng)
+	case GCJ02:
+	default:
+		return Point{}, fmt.Errorf("%w: %d", ErrUnknownDatum, int(datum))
+	}
+	return New(lat, lng, datum), nil
+}
+
+// GCJ02 converts the point to GCJ-02, the datum of the gateway.
+func (p Point) GCJ02() (Point, error) {
+	return p.To(GCJ02)
+}
+
+// Krasovsky 1940 ellipsoid used by GCJ-02.
+const (
+	semiMajorAxis = 6378245.0
+	eccentricity  = 0.00669342162296594323
+	xPi           = math.Pi * 3000.0 / 180.0
+)
+
+// outOfChina reports whether the coordinate is outside China, where GCJ-02 does not apply.
+func outOfChina(lat, lng float64) bool {
+	return lng < 72.004 || lng > 137.8347 || lat < 0.8293 || lat > 55.8271
+}
+
+func transformLat(x, y float64) float64 {
+	ret := -100.0 + 2.0*x + 3.0*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x))
+	ret += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	ret += (20.0*math.Sin(y*math.Pi) + 40.0*math.Sin(y/3.0*math.Pi)) * 2.0 / 3.0
+	ret += (160.0*math.Sin(y/12.0*math.Pi) + 320.0*math.Sin(y*math.Pi/30.0)) * 2.0 / 3.0
+	return ret
+}
+
+func transformLng(x, y float64) float64 {
+	ret := 300.0 + x + 2.0*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x))
+	ret += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	ret += (20.0*math.Sin(x*math.Pi) + 40.0*math.Sin(x/3.0*math.Pi)) * 2.0 / 3.0
+	ret += (150.0*math.Sin(x/12.0*math.Pi) + 300.0*math.Sin(x/30.0*math.Pi)) * 2.0 / 3.0
+	return ret
+}
+
+// offset returns the GCJ-02 offset of a WGS-84 coordinate.
+func offset(lat, lng float64) (float64, float64) {
+	dLat := transformLat(lng-105.0, lat-35.0)
+	dLng := transformLng(lng-105.0, lat-35.0)
+	radLat := lat / 180.0 * math.Pi
+	magic := math.Sin(radLat)
+	magic = 1 - eccentricity*magic*magic
+	sqrtMagic := math.Sqrt(magic)
+	dLat = (dLat * 180.0) / ((semiMajorAxis * (1 - eccentricity)) / (magic * sqrtMagic) * math.Pi)
+	dLng = (dLng * 180.0) / (semiMajorAxis / sqrtMagic * math.Cos(radLat) * math.Pi)
+	return dLat, dLng
+}
+
+func wgs84ToGCJ02(lat, lng float64) (float64, float64) {
+	if outOfChina(lat, lng) {
+		return lat, lng
+	}
+	dLat, dLng := offset(lat, lng)
+	return lat + dLat, lng + dLng
+}
+
+// gcj02ToWGS84 inverts wgs84ToGCJ02 iteratively, the error is below a millimetre.
+func gcj02ToWGS84(lat, lng float64) (float64, float64) {
+	if outOfChina(lat, lng) {
+		return lat, lng
+	}
+	wLat, wLng := lat, lng
+	for i := 0; i < 10; i++ {
+		gLat, gLng := wgs84ToGCJ02(wLat, wLng)
+		dLat, dLng := lat-gLat, lng-gLng
+		wLat, wLng = wLat+dLat, wLng+dLng
+		if math.Abs(dLat) < 1e-9 && math.Abs(dLng) < 1e-9 {
+			break
+		}
+	}
+	return wLat, wLng
+}
+
+func gcj02ToBD09(lat, lng float64) (float64, float64) {
+	z := math.Sqrt(lng*lng+lat*lat) + 0.00002*math.Sin(lat*xPi)
+	theta := math.Atan2(lat, lng) + 0.000003*math.Cos(lng*xPi)
+	return z*math.Sin(theta) + 0.006, z*math.Cos(theta) + 0.0065
+}
+
+func bd09ToGCJ02(lat, lng float64) (float64, float64) {
+	x, y := lng-0.0065, lat-0.006
+	z := math.Sqrt(x*x+y*y) - 0.00002*math.Sin(y*xPi)
+	theta := math.Atan2(y, x) - 0.000003*math.Cos(x*xPi)
+	return z * math.Sin(theta), z * math.Cos(theta)
+}
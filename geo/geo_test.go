@@ -0,0 +1,161 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package geo_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/houseme/imdadago/geo"
+)
+
+// metres returns the distance between two close points.
+func metres(a, b geo.Point) float64 {
+	const earthRadius = 6371000.0
+	dLat := (a.Lat - b.Lat) * math.Pi / 180
+	dLng := (a.Lng - b.Lng) * math.Pi / 180 * math.Cos(a.Lat*math.Pi/180)
+	return earthRadius * math.Hypot(dLat, dLng)
+}
+
+func TestToReferencePoints(t *testing.T) {
+	// Reference values of the published coordtransform algorithms at 39.915,116.404.
+	tests := []struct {
+		from geo.Point
+		to   geo.Datum
+		want geo.Point
+	}{
+		{geo.New(39.915, 116.404, geo.WGS84), geo.GCJ02, geo.New(39.91640428150164, 116.41024449916938, geo.GCJ02)},
+		{geo.New(39.915, 116.404, geo.GCJ02), geo.BD09, geo.New(39.92133699351021, 116.41036949371029, geo.BD09)},
+		{geo.New(39.915, 116.404, geo.BD09), geo.GCJ02, geo.New(39.90865673957631, 116.39762729119315, geo.GCJ02)},
+		{geo.New(39.91640428150164, 116.41024449916938, geo.GCJ02), geo.WGS84, geo.New(39.915, 116.404, geo.WGS84)},
+	}
+	for _, tt := range tests {
+		got, err := tt.from.To(tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Datum != tt.to || metres(got, tt.want) > 0.01 {
+			t.Errorf("%v.To(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		from, via geo.Datum
+		maxError  float64 // 米
+	}{
+		{geo.WGS84, geo.GCJ02, 0.001},
+		{geo.GCJ02, geo.WGS84, 0.001},
+		{geo.GCJ02, geo.BD09, 0.5},
+		{geo.BD09, geo.GCJ02, 0.5},
+		{geo.WGS84, geo.BD09, 0.5},
+		{geo.BD09, geo.WGS84, 0.5},
+	}
+	for _, tt := range tests {
+		var worst float64
+		for lat := 18.0; lat < 54; lat += 0.7 {
+			for lng := 74.0; lng < 135; lng += 0.9 {
+				p := geo.New(lat, lng, tt.from)
+				q, err := p.To(tt.via)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r, err := q.To(tt.from)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if e := metres(p, r); e > worst {
+					worst = e
+				}
+			}
+		}
+		if worst > tt.maxError {
+			t.Errorf("%s -> %s -> %s error %.6fm, want below %vm", tt.from, tt.via, tt.from, worst, tt.maxError)
+		}
+	}
+}
+
+func TestOutOfChina(t *testing.T) {
+	for _, p := range []geo.Point{
+		geo.New(48.8584, 2.2945, geo.WGS84),    // 巴黎
+		geo.New(-33.8568, 151.2153, geo.WGS84), // 悉尼
+		geo.New(40.6892, -74.0445, geo.WGS84),  // 纽约
+	} {
+		g, err := p.GCJ02()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Lat != p.Lat || g.Lng != p.Lng || g.Datum != geo.GCJ02 {
+			t.Errorf("%v.GCJ02() = %v, want the same coordinate", p, g)
+		}
+		w, err := g.To(geo.WGS84)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w != p {
+			t.Errorf("%v.To(WGS-84) = %v, want %v", g, w, p)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		p   geo.Point
+		err error
+	}{
+		{geo.New(31.23, 121.47, geo.GCJ02), nil},
+		{geo.New(90, 180, geo.WGS84), nil},
+		{geo.New(-90, -180, geo.BD09), nil},
+		{geo.New(31.23, 121.47, 0), geo.ErrUnknownDatum},
+		{geo.New(31.23, 121.47, 4), geo.ErrUnknownDatum},
+		{geo.New(90.1, 121.47, geo.GCJ02), geo.ErrOutOfRange},
+		{geo.New(31.23, -180.1, geo.GCJ02), geo.ErrOutOfRange},
+		{geo.New(math.NaN(), 121.47, geo.GCJ02), geo.ErrOutOfRange},
+		{geo.New(31.23, math.NaN(), geo.GCJ02), geo.ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		if err := tt.p.Validate(); !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("%v.Validate() = %v, want %v", tt.p, err, tt.err)
+		}
+		if _, err := tt.p.To(geo.WGS84); tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("%v.To(WGS-84) = %v, want %v", tt.p, err, tt.err)
+		}
+	}
+	if _, err := geo.New(31.23, 121.47, geo.GCJ02).To(9); !errors.Is(err, geo.ErrUnknownDatum) {
+		t.Errorf("To(unknown datum) = %v, want ErrUnknownDatum", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	p, err := geo.Parse("31.230416", "121.473701", geo.GCJ02)
+	if err != nil || p != geo.New(31.230416, 121.473701, geo.GCJ02) {
+		t.Errorf("Parse() = %v, %v", p, err)
+	}
+	if _, err = geo.Parse("x", "121.47", geo.GCJ02); err == nil {
+		t.Error("Parse() accepted an invalid latitude")
+	}
+	if _, err = geo.Parse("31.23", "", geo.GCJ02); err == nil {
+		t.Error("Parse() accepted an empty longitude")
+	}
+	if _, err = geo.Parse("91", "121.47", geo.GCJ02); !errors.Is(err, geo.ErrOutOfRange) {
+		t.Errorf("Parse(91) = %v, want ErrOutOfRange", err)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const testShopNo = "test-shop"
+
+// newTestClient starts a fake gateway with a shop and returns a client of it.
+func newTestClient(t *testing.T, serverOpts []dadatest.Option, opts ...dadago.Option) (*dadatest.Server, *dadago.Client) {
+	t.Helper()
+	s := dadatest.NewServer(serverOpts...)
+	s.AddShop(domain.ShopQueryItem{OriginShopID: testShopNo, StationName: "测试门店"})
+	opts = append(append(s.ClientOptions(),
+		dadago.WithLogPath(t.TempDir()),
+		dadago.WithLevel(dadago.Level(hlog.LevelError)),
+	), opts...)
+	c := dadago.New(context.Background(), opts...)
+	t.Cleanup(func() {
+		_ = c.Close()
+		s.Close()
+	})
+	return s, c
+}
+
+// newOrder returns a valid order of the test shop.
+func newOrder(originID string) *domain.OrdersCreateRequest {
+	return &domain.OrdersCreateRequest{
+		ShopNo:          testShopNo,
+		OriginID:        originID,
+		CargoPrice:      domain.Yuan(20),
+		ReceiverName:    "收件人" + originID,
+		ReceiverAddress: "测试地址",
+		ReceiverLat:     31.230416,
+		ReceiverLng:     121.473701,
+		ReceiverPhone:   "13800000000",
+		CargoWeight:     1,
+	}
+}
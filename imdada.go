@@ -21,20 +21,15 @@ package dadago
 import (
 	"context"
 	"crypto/md5"
-	"crypto/tls"
 	"encoding/hex"
-	"errors"
+	"io"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/bytedance/sonic"
-	"github.com/cloudwego/hertz/pkg/app/client"
 	"github.com/cloudwego/hertz/pkg/common/hlog"
-	"github.com/cloudwego/hertz/pkg/protocol"
-	"github.com/cloudwego/hertz/pkg/protocol/consts"
 
 	"github.com/houseme/imdadago/domain"
 	"github.com/houseme/imdadago/internal/log"
@@ -48,6 +43,8 @@ func New(ctx context.Context, opts ...Option) *Client {
 		Gateway:   gateway,
 		Level:     Level(hlog.LevelDebug),
 		LogPath:   os.TempDir(),
+
+		CancelReasonsTTL: time.Hour,
 	}
 
 	for _, option := range opts {
@@ -55,511 +52,337 @@ func New(ctx context.Context, opts ...Option) *Client {
 	}
 
 	c := &Client{
-		op:       op,
-		log:      log.InitLog(ctx, op.LogPath, hlog.Level(op.Level)),
-		response: &protocol.Response{},
-		request: &domain.Request{
-			AppKey:   op.AppKey,
-			V:        version,
-			Format:   format,
-			SourceID: op.SourceID,
-		},
+		op:  op,
+		log: log.InitLog(ctx, op.LogPath, hlog.Level(op.Level)),
 	}
 	c.log.SetLevel(hlog.Level(op.Level))
+	if c.transport = op.Transport; c.transport == nil {
+		c.ownTransport = true
+		if c.transport, c.err = newHertzTransport(op); c.err != nil {
+			c.log.CtxErrorf(ctx, "im dada init http client failed: %v", c.err)
+		}
+	}
+	interceptors := []Interceptor{LoggingInterceptor(c.log)}
+	if op.Metrics != nil {
+		interceptors = append(interceptors, MetricsInterceptor(op.Metrics))
+	}
+	c.handler = chain(append(interceptors, op.Interceptors...), c.invoke)
 	c.log.CtxInfof(ctx, "im dada init client start level:%s", op.Level)
 	return c
 }
 
+// Close releases the transport created by New and flushes the logger,
+// a transport passed with WithTransport is left to the caller.
+// The Client must not be used after Close.
+func (c *Client) Close() (err error) {
+	if closer, ok := c.transport.(io.Closer); ok && c.ownTransport {
+		err = closer.Close()
+	}
+	if s, ok := c.log.(interface{ Sync() }); ok {
+		s.Sync()
+	}
+	return
+}
+
+// newRequest creates the request envelope of a single call.
+// Every call owns its envelope, so one Client can be shared by many goroutines.
+func (c *Client) newRequest() *domain.Request {
+	return &domain.Request{
+		AppKey:   c.op.AppKey,
+		V:        version,
+		Format:   format,
+		SourceID: c.op.SourceID,
+	}
+}
+
 // generateTimestamp Generate current time
-func (c *Client) generateTimestamp() {
-	c.request.Timestamp = time.Now().Unix()
+func (c *Client) generateTimestamp(request *domain.Request) {
+	request.Timestamp = time.Now().Unix()
 }
 
 // md5Sign
-func (c *Client) md5Sign() {
+func (c *Client) md5Sign(request *domain.Request) {
+	request.Signature = Sign(c.op.AppSecret, request)
+}
+
+// Sign returns the signature of the request envelope signed with appSecret.
+func Sign(appSecret string, request *domain.Request) string {
 	var builder strings.Builder
-	builder.WriteString(c.op.AppSecret)
-	builder.WriteString("app_key" + c.request.AppKey)
-	builder.WriteString("body" + c.request.Body)
-	builder.WriteString("format" + c.request.Format)
-	builder.WriteString("source_id" + c.request.SourceID)
-	builder.WriteString("timestamp" + strconv.FormatInt(c.request.Timestamp, 10))
-	builder.WriteString("v" + c.request.V)
-	builder.WriteString(c.op.AppSecret)
+	builder.WriteString(appSecret)
+	builder.WriteString("app_key" + request.AppKey)
+	builder.WriteString("body" + request.Body)
+	builder.WriteString("format" + request.Format)
+	builder.WriteString("source_id" + request.SourceID)
+	builder.WriteString("timestamp" + strconv.FormatInt(request.Timestamp, 10))
+	builder.WriteString("v" + request.V)
+	builder.WriteString(appSecret)
 	h := md5.New()
 	h.Write([]byte(builder.String()))
-	c.request.Signature = strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
 }
 
-// initRequest
-func (c *Client) initRequest(method string) {
-	c.generateTimestamp()
-	c.md5Sign()
-	c.gateway = c.op.Gateway + method
+// initRequest signs the request and returns the url of the method.
+func (c *Client) initRequest(method string, request *domain.Request) string {
+	c.generateTimestamp(request)
+	c.md5Sign(request)
+	return c.op.Gateway + method
 }
 
-// doRequest does the request.
-func (c *Client) doRequest(ctx context.Context, method string) error {
-	c.initRequest(method)
-	c.log.CtxDebugf(ctx, "request data: %+v", c.request)
-	jsonBytes, err := sonic.Marshal(c.request)
-	if err != nil {
-		return err
+// doRequest does the request and returns the response body.
+func (c *Client) doRequest(ctx context.Context, method string, request *domain.Request, header map[string]string) ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
 	}
-	c.log.CtxDebugf(ctx, "jsonBytes: %s", string(jsonBytes))
-	request := &protocol.Request{}
-	request.SetBody(jsonBytes)
-	request.Header.SetContentTypeBytes([]byte("application/json"))
-	request.Header.Set("accept", "application/json")
-	c.log.CtxDebugf(ctx, "request url: %s", c.gateway)
-	request.SetRequestURI(c.gateway)
-	request.Header.SetMethod(consts.MethodPost)
-	request.Header.SetUserAgentBytes(c.op.UserAgent)
-	c.log.CtxDebugf(ctx, "request create end")
-
-	hertz, err := client.NewClient(client.WithTLSConfig(&tls.Config{
-		InsecureSkipVerify: true,
-	}), client.WithDialTimeout(c.op.TimeOut))
-	if err != nil {
-		return err
+	if err := c.waitRateLimit(ctx, method); err != nil {
+		return nil, err
+	}
+	url := c.initRequest(method, request)
+
+	breaker := c.op.CircuitBreaker
+	var generation uint64
+	if breaker != nil {
+		var err error
+		if generation, err = breaker.allow(); err != nil {
+			c.log.CtxWarnf(ctx, "%s rejected: %v", method, err)
+			return nil, err
+		}
 	}
 
-	c.log.CtxDebugf(ctx, "do request start")
-	if err = hertz.Do(ctx, request, c.response); err != nil {
-		return err
+	body, err := c.send(ctx, method, &TransportRequest{
+		URL:       url,
+		UserAgent: c.op.UserAgent,
+		Header:    header,
+		Envelope:  request,
+	})
+	if breaker != nil {
+		breaker.record(generation, err)
 	}
-	return nil
+	return body, err
 }
 
-// QueryBalance query balance.
-// 查询账户余额 url: http://newopen.imdada.cn/#/development/file/balanceQuery
-func (c *Client) QueryBalance(ctx context.Context, req *domain.QueryBalanceRequest) (resp *domain.QueryBalanceResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
+// send sends the signed request with the transport and checks the response.
+func (c *Client) send(ctx context.Context, method string, req *TransportRequest) ([]byte, error) {
+	resp, err := c.transport.Do(ctx, req)
+	if err != nil {
 		return nil, err
 	}
-	c.log.CtxDebugf(ctx, "QueryBalance request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, queryBalance); err != nil {
+	if err = checkResponse(method, resp.StatusCode, resp.Body); err != nil {
 		return nil, err
 	}
-	c.log.CtxDebugf(ctx, "QueryBalance response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+	return resp.Body, nil
+}
+
+// waitRateLimit waits for a token of the rate limiter.
+func (c *Client) waitRateLimit(ctx context.Context, method string) error {
+	limiter := c.op.RateLimiter
+	if limiter == nil {
+		return nil
+	}
+	wait, err := limiter.Wait(ctx, method)
+	if wait > 0 || err != nil {
+		c.log.CtxInfof(ctx, "%s rate limit wait: %s, err: %v", method, wait, err)
+		if limiter.cfg.OnWait != nil {
+			limiter.cfg.OnWait(ctx, method, wait, err)
+		}
+		if c.op.Metrics != nil {
+			c.op.Metrics.ObserveRateLimitWait(method, wait)
+		}
 	}
-	return
+	return err
+}
+
+// QueryBalance query balance.
+// 查询账户余额 url: http://newopen.imdada.cn/#/development/file/balanceQuery
+func (c *Client) QueryBalance(ctx context.Context, req *domain.QueryBalanceRequest) (*domain.QueryBalanceResponse, error) {
+	return Invoke[domain.QueryBalanceRequest, domain.QueryBalanceResponse](ctx, c, queryBalance, req)
 }
 
 // Recharge account recharge.
 // 获取充值链接 url: http://newopen.imdada.cn/#/development/file/recharge
-func (c *Client) Recharge(ctx context.Context, req *domain.RechargeRequest) (resp *domain.RechargeResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "Recharge request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, recharge); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "Recharge response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) Recharge(ctx context.Context, req *domain.RechargeRequest) (*domain.RechargeResponse, error) {
+	return Invoke[domain.RechargeRequest, domain.RechargeResponse](ctx, c, recharge, req)
 }
 
 // CreateMerchant create merchant.
 // 添加商户 url: http://newopen.imdada.cn/#/development/file/merchantAdd
-func (c *Client) CreateMerchant(ctx context.Context, req *domain.MerchantCreateRequest) (resp *domain.MerchantCreateResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.request.SourceID = ""
-	c.log.CtxDebugf(ctx, "CreateMerchant request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, merchantCreate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateMerchant response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) CreateMerchant(ctx context.Context, req *domain.MerchantCreateRequest) (*domain.MerchantCreateResponse, error) {
+	return Invoke[domain.MerchantCreateRequest, domain.MerchantCreateResponse](ctx, c, merchantCreate, req)
 }
 
 // CreateShop create shop.
 // 添加门店 url: http://newopen.imdada.cn/#/development/file/shopAdd
-func (c *Client) CreateShop(ctx context.Context, req *domain.ShopCreateRequest) (resp *domain.ShopCreateResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateShop request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, shopCreate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateShop response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) CreateShop(ctx context.Context, req *domain.ShopCreateRequest) (*domain.ShopCreateResponse, error) {
+	if req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
-	return
+	return Invoke[domain.ShopCreateRequest, domain.ShopCreateResponse](ctx, c, shopCreate, req)
 }
 
 // ModifyShop modify shop.
 // 编辑门店 url: http://newopen.imdada.cn/#/development/file/shopUpdate
-func (c *Client) ModifyShop(ctx context.Context, req *domain.ShopUpdateRequest) (resp *domain.ShopUpdateResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ModifyShop request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, shopUpdate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ModifyShop response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) ModifyShop(ctx context.Context, req *domain.ShopUpdateRequest) (*domain.ShopUpdateResponse, error) {
+	if req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
-	return
+	return Invoke[domain.ShopUpdateRequest, domain.ShopUpdateResponse](ctx, c, shopUpdate, req)
 }
 
 // QueryShop query shop.
 // 门店详情 url: http://newopen.imdada.cn/#/development/file/shopDetail
-func (c *Client) QueryShop(ctx context.Context, req *domain.ShopQueryRequest) (resp *domain.ShopQueryResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryShop request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, shopQuery); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryShop response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) QueryShop(ctx context.Context, req *domain.ShopQueryRequest) (*domain.ShopQueryResponse, error) {
+	return Invoke[domain.ShopQueryRequest, domain.ShopQueryResponse](ctx, c, shopQuery, req)
 }
 
 // QueryCity query city list
 // 获取城市信息列表 http://newopen.imdada.cn/#/development/file/cityList
-func (c *Client) QueryCity(ctx context.Context, req *domain.CityListQueryRequest) (resp *domain.CityListQueryResponse, err error) {
-	if req == nil {
-		c.request.Body = ""
-	} else {
-		if c.request.Body, err = sonic.MarshalString(req); err != nil {
-			return nil, err
-		}
-	}
-	c.log.CtxDebugf(ctx, "QueryCity request data: %s ", c.request.Body)
-	if err = c.doRequest(ctx, cityCodeList); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryCity response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) QueryCity(ctx context.Context, req *domain.CityListQueryRequest) (*domain.CityListQueryResponse, error) {
+	return Invoke[domain.CityListQueryRequest, domain.CityListQueryResponse](ctx, c, cityCodeList, req)
 }
 
 // CreateOrder create order.
 // 添加订单 url: http://newopen.imdada.cn/#/development/file/add
-func (c *Client) CreateOrder(ctx context.Context, req *domain.OrdersCreateRequest) (resp *domain.OrdersCreateResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateOrder request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, ordersCreate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateOrder response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) CreateOrder(ctx context.Context, req *domain.OrdersCreateRequest) (*domain.OrdersCreateResponse, error) {
+	if c.op.ValidateRequests && req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
-
-	return
+	return Invoke[domain.OrdersCreateRequest, domain.OrdersCreateResponse](ctx, c, ordersCreate, req)
 }
 
 // ReCreateOrder recreate order.
 // 重新发布订单 url: http://newopen.imdada.cn/#/development/file/reAdd
-func (c *Client) ReCreateOrder(ctx context.Context, req *domain.OrdersCreateRequest) (resp *domain.OrdersCreateResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ReCreateOrder request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderReCreate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ReCreateOrder response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) ReCreateOrder(ctx context.Context, req *domain.OrdersCreateRequest) (*domain.OrdersCreateResponse, error) {
+	if c.op.ValidateRequests && req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
-
-	return
+	return Invoke[domain.OrdersCreateRequest, domain.OrdersCreateResponse](ctx, c, orderReCreate, req)
 }
 
 // QueryDeliverFee query deliver fee.
 // 订单运费查询 url: http://newopen.imdada.cn/#/development/file/readyAdd
-func (c *Client) QueryDeliverFee(ctx context.Context, req *domain.DeliverFeeQueryRequest) (resp *domain.DeliverFeeQueryResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryDeliverFee request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderDeliverFeeQuery); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryDeliverFee response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) QueryDeliverFee(ctx context.Context, req *domain.DeliverFeeQueryRequest) (*domain.DeliverFeeQueryResponse, error) {
+	if c.op.ValidateRequests && req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
-
-	return
+	return Invoke[domain.DeliverFeeQueryRequest, domain.DeliverFeeQueryResponse](ctx, c, orderDeliverFeeQuery, req)
 }
 
 // OrdersCreateByDeliverFeeQuery create order by deliver the fee query.
 // 通过运费接口创建订单 url: http://newopen.imdada.cn/#/development/file/addAfterQuery
-func (c *Client) OrdersCreateByDeliverFeeQuery(ctx context.Context, req *domain.OrdersCreateByDeliverFeeQueryRequest) (resp *domain.OrdersCreateByDeliverFeeQueryResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrdersCreateByDeliverFeeQuery request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderCreateAfterQuery); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrdersCreateByDeliverFeeQuery response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) OrdersCreateByDeliverFeeQuery(ctx context.Context, req *domain.OrdersCreateByDeliverFeeQueryRequest) (*domain.OrdersCreateByDeliverFeeQueryResponse, error) {
+	return Invoke[domain.OrdersCreateByDeliverFeeQueryRequest, domain.OrdersCreateByDeliverFeeQueryResponse](ctx, c, orderCreateAfterQuery, req)
 }
 
 // OrdersAddTip add tip.
 // 添加小费 url: http://newopen.imdada.cn/#/development/file/addTip
-func (c *Client) OrdersAddTip(ctx context.Context, req *domain.OrdersAddTipRequest) (resp *domain.OrdersAddTipResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrdersAddTip request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderAddTip); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrdersAddTip response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) OrdersAddTip(ctx context.Context, req *domain.OrdersAddTipRequest) (*domain.OrdersAddTipResponse, error) {
+	return Invoke[domain.OrdersAddTipRequest, domain.OrdersAddTipResponse](ctx, c, orderAddTip, req)
 }
 
 // QueryOrderStatus query order status.
 // 订单详情查询 url: http://newopen.imdada.cn/#/development/file/statusQuery
-func (c *Client) QueryOrderStatus(ctx context.Context, req *domain.OrdersQueryRequest) (resp *domain.OrdersQueryResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryOrderStatus request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderStatusQuery); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryOrderStatus response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) QueryOrderStatus(ctx context.Context, req *domain.OrdersQueryRequest) (*domain.OrdersQueryResponse, error) {
+	return Invoke[domain.OrdersQueryRequest, domain.OrdersQueryResponse](ctx, c, orderStatusQuery, req)
 }
 
 // CancelOrder cancel order.
 // 取消订单 url: http://newopen.imdada.cn/#/development/file/formalCancel
-func (c *Client) CancelOrder(ctx context.Context, req *domain.OrdersCancelRequest) (resp *domain.OrdersCancelResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CancelOrder request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderCancel); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CancelOrder response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
+func (c *Client) CancelOrder(ctx context.Context, req *domain.OrdersCancelRequest) (*domain.OrdersCancelResponse, error) {
+	if req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 	}
+	return Invoke[domain.OrdersCancelRequest, domain.OrdersCancelResponse](ctx, c, orderCancel, req)
+}
 
-	return
+// QueryCancelReasons query the cancel reasons, see CancelReasons for the cached list.
+// 取消原因列表 url: http://newopen.imdada.cn/#/development/file/reasonList
+func (c *Client) QueryCancelReasons(ctx context.Context, req *domain.CancelReasonsRequest) (*domain.CancelReasonsResponse, error) {
+	return Invoke[domain.CancelReasonsRequest, domain.CancelReasonsResponse](ctx, c, orderCancelReasons, req)
 }
 
 // AdditionalOrders additional order.
 // 增加订单 url: http://newopen.imdada.cn/#/development/file/appointOrder
-func (c *Client) AdditionalOrders(ctx context.Context, req *domain.OrdersAddAppointRequest) (resp *domain.OrdersAddAppointResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "AdditionalOrders request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, additionalOrders); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "AdditionalOrders response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) AdditionalOrders(ctx context.Context, req *domain.OrdersAddAppointRequest) (*domain.OrdersAddAppointResponse, error) {
+	return Invoke[domain.OrdersAddAppointRequest, domain.OrdersAddAppointResponse](ctx, c, additionalOrders, req)
 }
 
 // CancelTheAddOnOrder cancel appoint order CancelTheAddOnOrder
 // 取消预约单 url: http://newopen.imdada.cn/#/development/file/appointOrderCancel
-func (c *Client) CancelTheAddOnOrder(ctx context.Context, req *domain.OrdersCancelAppointRequest) (resp *domain.OrdersCancelAppointResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CancelTheAddOnOrder request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, cancelAppointOrders); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CancelTheAddOnOrder response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) CancelTheAddOnOrder(ctx context.Context, req *domain.OrdersCancelAppointRequest) (*domain.OrdersCancelAppointResponse, error) {
+	return Invoke[domain.OrdersCancelAppointRequest, domain.OrdersCancelAppointResponse](ctx, c, cancelAppointOrders, req)
 }
 
 // QueriesCanAppendKnights query can append knights.
 // 查询可追加骑士 url: http://newopen.imdada.cn/#/development/file/listTransportersToAppoint
-func (c *Client) QueriesCanAppendKnights(ctx context.Context, req *domain.OrdersAppointTransporterRequest) (resp *domain.OrdersAppointTransporterResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueriesCanAppendKnights request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, transportAppointList); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueriesCanAppendKnights response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) QueriesCanAppendKnights(ctx context.Context, req *domain.OrdersAppointTransporterRequest) (*domain.OrdersAppointTransporterResponse, error) {
+	return Invoke[domain.OrdersAppointTransporterRequest, domain.OrdersAppointTransporterResponse](ctx, c, transportAppointList, req)
 }
 
 // CreateAComplaint create a complaint.
 // 创建投诉 url: http://newopen.imdada.cn/#/development/file/complaintDada
-func (c *Client) CreateAComplaint(ctx context.Context, req *domain.ComplaintRequest) (resp *domain.ComplaintResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateAComplaint request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, complaintCreate); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "CreateAComplaint response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) CreateAComplaint(ctx context.Context, req *domain.ComplaintRequest) (*domain.ComplaintResponse, error) {
+	return Invoke[domain.ComplaintRequest, domain.ComplaintResponse](ctx, c, complaintCreate, req)
 }
 
 // QueryComplaint query complaint.
 // 查询投诉 url: http://newopen.imdada.cn/#/development/file/queryComplaintDada
-func (c *Client) QueryComplaint(ctx context.Context, req *domain.ComplaintReasonRequest) (resp *domain.ComplaintReasonResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryComplaint request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, complaintReasons); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryComplaint response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-
-	return
+func (c *Client) QueryComplaint(ctx context.Context, req *domain.ComplaintReasonRequest) (*domain.ComplaintReasonResponse, error) {
+	return Invoke[domain.ComplaintReasonRequest, domain.ComplaintReasonResponse](ctx, c, complaintReasons, req)
 }
 
 // OrderConfirmGoods order confirm goods.
 // 商户确认物品已返还
-func (c *Client) OrderConfirmGoods(ctx context.Context, req *domain.OrdersConfirmGoodsRequest) (resp *domain.OrdersConfirmGoodsResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrderConfirmGoods request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, orderConfirmGoods); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrderConfirmGoods response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) OrderConfirmGoods(ctx context.Context, req *domain.OrdersConfirmGoodsRequest) (*domain.OrdersConfirmGoodsResponse, error) {
+	return Invoke[domain.OrdersConfirmGoodsRequest, domain.OrdersConfirmGoodsResponse](ctx, c, orderConfirmGoods, req)
 }
 
 // OrderConfirmCancel order confirm cancel.
 // 商户审核骑士取消订单 url: http://newopen.imdada.cn/#/development/file/applicationCancel
-func (c *Client) OrderConfirmCancel(ctx context.Context, req *domain.OrdersTransporterCancelAsyncConfirmRequest) (resp *domain.OrdersTransporterCancelAsyncConfirmResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrderConfirmCancel request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, messageConfirm); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "OrderConfirmCancel response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) OrderConfirmCancel(ctx context.Context, req *domain.OrdersTransporterCancelAsyncConfirmRequest) (*domain.OrdersTransporterCancelAsyncConfirmResponse, error) {
+	return Invoke[domain.OrdersTransporterCancelAsyncConfirmRequest, domain.OrdersTransporterCancelAsyncConfirmResponse](ctx, c, messageConfirm, req)
 }
 
 // QueryTransporterPosition query transporter position.
 // 查询骑士位置 url: http://newopen.imdada.cn/#/development/file/queryLocation
-func (c *Client) QueryTransporterPosition(ctx context.Context, req *domain.OrdersTransporterPositionRequest) (resp *domain.OrdersTransporterPositionResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryTransporterPosition request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, transporterPosition); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryTransporterPosition response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) QueryTransporterPosition(ctx context.Context, req *domain.OrdersTransporterPositionRequest) (*domain.OrdersTransporterPositionResponse, error) {
+	return Invoke[domain.OrdersTransporterPositionRequest, domain.OrdersTransporterPositionResponse](ctx, c, transporterPosition, req)
 }
 
 // QueryTransporterTrack query transporter track.
 // 查询骑士轨迹 url: http://newopen.imdada.cn/#/development/file/queryDeliverTrack
-func (c *Client) QueryTransporterTrack(ctx context.Context, req *domain.OrdersTransporterTrackRequest) (resp *domain.OrdersTransporterTrackResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryTransporterTrack request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, transporterTrack); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "QueryTransporterTrack response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) QueryTransporterTrack(ctx context.Context, req *domain.OrdersTransporterTrackRequest) (*domain.OrdersTransporterTrackResponse, error) {
+	return Invoke[domain.OrdersTransporterTrackRequest, domain.OrdersTransporterTrackResponse](ctx, c, transporterTrack, req)
 }
 
 // ModifyFetchCode modify fetch code.
 // 修改取货码 url: http://newopen.imdada.cn/#/development/file/modifyFetchCode
-func (c *Client) ModifyFetchCode(ctx context.Context, req *domain.OrdersFetchCodeModifyRequest) (resp *domain.OrdersFetchCodeModifyResponse, err error) {
-	if c.request.Body, err = sonic.MarshalString(req); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ModifyFetchCode request data: %s", c.request.Body)
-	if err = c.doRequest(ctx, fetchCodeModify); err != nil {
-		return nil, err
-	}
-	c.log.CtxDebugf(ctx, "ModifyFetchCode response data: %s", string(c.response.Body()))
-	if err = sonic.Unmarshal(c.response.Body(), &resp); err != nil {
-		return nil, err
-	}
-	return
+func (c *Client) ModifyFetchCode(ctx context.Context, req *domain.OrdersFetchCodeModifyRequest) (*domain.OrdersFetchCodeModifyResponse, error) {
+	return Invoke[domain.OrdersFetchCodeModifyRequest, domain.OrdersFetchCodeModifyResponse](ctx, c, fetchCodeModify, req)
 }
 
 //
 
-// VerifySignature verify signature.
+// VerifySignature verifies the signature of an order status callback, it returns ErrCallbackSignature on mismatch.
 func (c *Client) VerifySignature(_ context.Context, updateTime int64, clientID, orderID, signature string) (err error) {
+	if CallbackSignature(updateTime, clientID, orderID) != signature {
+		return ErrCallbackSignature
+	}
+	return
+}
+
+// CallbackSignature returns the signature of an order status callback.
+func CallbackSignature(updateTime int64, clientID, orderID string) string {
 	var list []string
 	list = append(list, strconv.FormatInt(updateTime, 10))
 	list = append(list, clientID)
@@ -568,8 +391,5 @@ func (c *Client) VerifySignature(_ context.Context, updateTime int64, clientID,
 	sign := strings.Join(list, "")
 	h := md5.New()
 	h.Write([]byte(sign))
-	if hex.EncodeToString(h.Sum(nil)) != signature {
-		return errors.New("signature error")
-	}
-	return
+	return hex.EncodeToString(h.Sum(nil))
 }
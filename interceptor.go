@@ -0,0 +1,73 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"time"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// Invocation is an API call seen by the interceptors.
+type Invocation struct {
+	Path        string            // 接口路径，如 "/api/order/addOrder"
+	Request     any               // 业务请求参数，如 *domain.OrdersCreateRequest
+	Envelope    *domain.Request   // 已签名的请求报文，在 next 返回后为最后一次尝试的签名报文
+	Header      map[string]string // 附加的 http 请求头
+	Response    any               // 解码目标，如 *domain.OrdersCreateResponse，可以为 nil
+	RawResponse []byte            // 原始响应内容，在 next 返回后可用
+	Attempts    int               // 请求次数，包含重试
+}
+
+// Handler performs an invocation.
+type Handler func(ctx context.Context, inv *Invocation) error
+
+// Interceptor wraps every API call. It may inspect or mutate inv before calling next,
+// inspect the response after next returns, or short-circuit by returning without calling next.
+// The envelope is signed before the first interceptor runs. It is encoded from Request and signed
+// again before it is sent, so change Request rather than the envelope body; every retry signs it anew.
+type Interceptor func(ctx context.Context, inv *Invocation, next Handler) error
+
+// chain builds the handler calling the interceptors in order around h.
+func chain(interceptors []Interceptor, h Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], h
+		h = func(ctx context.Context, inv *Invocation) error {
+			return interceptor(ctx, inv, next)
+		}
+	}
+	return h
+}
+
+// LoggingInterceptor logs the request and the response of every call, it is installed by default.
+func LoggingInterceptor(logger Logger) Interceptor {
+	return func(ctx context.Context, inv *Invocation, next Handler) error {
+		start := time.Now()
+		logger.CtxDebugf(ctx, "%s request: %+v", inv.Path, inv.Request)
+		err := next(ctx, inv)
+		logger.CtxDebugf(ctx, "%s request data: %+v", inv.Path, inv.Envelope)
+		if err != nil {
+			logger.CtxErrorf(ctx, "%s request failed, attempts: %d, cost: %s, err: %v", inv.Path, inv.Attempts, time.Since(start), err)
+			return err
+		}
+		logger.CtxDebugf(ctx, "%s response data: %s, attempts: %d, cost: %s", inv.Path, inv.RawResponse, inv.Attempts, time.Since(start))
+		return nil
+	}
+}
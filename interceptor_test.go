@@ -0,0 +1,127 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+// newInterceptorClient returns a client with the interceptors, recording the requests it sends.
+func newInterceptorClient(t *testing.T, interceptors ...dadago.Interceptor) (*dadatest.Server, *dadago.Client, *recordingTransport) {
+	t.Helper()
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	serverOpts := []dadatest.Option{dadatest.WithAppSecret(dadatestSecret)}
+	s, c := newTestClient(t, serverOpts, dadago.WithTransport(rec), dadago.WithInterceptors(interceptors...))
+	return s, c, rec
+}
+
+func TestInterceptorOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) dadago.Interceptor {
+		return func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+			calls = append(calls, name+" before")
+			err := next(ctx, inv)
+			calls = append(calls, name+" after")
+			return err
+		}
+	}
+	_, c, _ := newInterceptorClient(t, trace("first"), trace("second"))
+	if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"first before", "second before", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls %v, want %v", calls, want)
+	}
+}
+
+func TestInterceptorSeesSignedEnvelope(t *testing.T) {
+	var before domain.Request
+	check := func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		before = *inv.Envelope
+		return next(ctx, inv)
+	}
+	_, c, _ := newInterceptorClient(t, check)
+	if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if before.Body != `{"category":3}` || before.Timestamp == 0 {
+		t.Errorf("envelope before next %+v, want the encoded request", before)
+	}
+	if want := dadago.Sign(dadatestSecret, &before); before.Signature != want {
+		t.Errorf("signature before next %q, want %q", before.Signature, want)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	var reached bool
+	cached := func(_ context.Context, inv *dadago.Invocation, _ dadago.Handler) error {
+		if resp, ok := inv.Response.(*domain.QueryBalanceResponse); ok {
+			resp.Status, resp.Result = "success", &domain.BalanceResult{DeliverBalance: domain.Yuan(7)}
+		}
+		return nil
+	}
+	inner := func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		reached = true
+		return next(ctx, inv)
+	}
+	_, c, rec := newInterceptorClient(t, cached, inner)
+
+	resp, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1})
+	if err != nil || resp.Result == nil || resp.Result.DeliverBalance != domain.Yuan(7) {
+		t.Fatalf("QueryBalance() = %+v, %v, want the cached balance", resp, err)
+	}
+	if reached {
+		t.Error("the interceptor after the short-circuit ran")
+	}
+	if n := len(rec.sent); n != 0 {
+		t.Errorf("%d requests sent, want none", n)
+	}
+}
+
+func TestInterceptorMutatesRequest(t *testing.T) {
+	rename := func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		if req, ok := inv.Request.(*domain.OrdersCreateRequest); ok {
+			req.ReceiverName = "改名"
+		}
+		return next(ctx, inv)
+	}
+	s, c, rec := newInterceptorClient(t, rename)
+	if _, err := c.CreateOrder(context.Background(), newOrder("mutated")); err != nil {
+		t.Fatal(err)
+	}
+	if o, ok := s.Order("mutated"); !ok || o.Request.ReceiverName != "改名" {
+		t.Errorf("order %+v, want the receiver set by the interceptor", o.Request)
+	}
+	sent := rec.attempts(createOrderPath)
+	if len(sent) != 1 || !strings.Contains(sent[0].Body, `"改名"`) {
+		t.Fatalf("sent %+v, want the mutated body", sent)
+	}
+	if want := dadago.Sign(dadatestSecret, &sent[0]); sent[0].Signature != want {
+		t.Errorf("signature %q of the mutated body, want %q", sent[0].Signature, want)
+	}
+}
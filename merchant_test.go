@@ -0,0 +1,60 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestShopRejectsUnsupportedBusiness(t *testing.T) {
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	_, c := newTestClient(t, nil, dadago.WithTransport(rec))
+	ctx := context.Background()
+
+	create := domain.ShopCreateRequest{
+		{StationName: "门店一", OriginShopID: "s1", Business: domain.BusinessCake},
+		{StationName: "门店二", OriginShopID: "s2", Business: domain.Business(4)},
+	}
+	if _, err := c.CreateShop(ctx, &create); !errors.Is(err, domain.ErrUnsupportedBusiness) {
+		t.Errorf("CreateShop() = %v, want ErrUnsupportedBusiness", err)
+	}
+	update := &domain.ShopUpdateRequest{OriginShopID: testShopNo, Business: domain.Business(99)}
+	if _, err := c.ModifyShop(ctx, update); !errors.Is(err, domain.ErrUnsupportedBusiness) {
+		t.Errorf("ModifyShop() = %v, want ErrUnsupportedBusiness", err)
+	}
+	if n := len(rec.sent); n != 0 {
+		t.Fatalf("%d requests sent, want none", n)
+	}
+
+	// A zero category keeps the current one and is sent.
+	update.Business = 0
+	update.StationName = "新门店名"
+	if _, err := c.ModifyShop(ctx, update); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rec.sent); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+}
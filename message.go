@@ -0,0 +1,187 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// MessageTypeTransporterCancel is the message type of a rider cancellation request.
+// 消息类型（1：骑士取消订单推送消息）
+const MessageTypeTransporterCancel = 1
+
+// defaultDecideTimeout bounds the policy and the confirmation of a message, the gateway waits for the reply.
+const defaultDecideTimeout = 5 * time.Second
+
+// CancelDecision is the decision on a rider cancellation request.
+type CancelDecision struct {
+	Confirm      bool     // 是否同意取消
+	Escalate     bool     // 转人工处理，不调用审核接口
+	Imgs         []string // 审核不通过的图片列表
+	RejectReason string   // 拒绝原因
+}
+
+// CancelPolicy decides on a rider cancellation request.
+type CancelPolicy func(ctx context.Context, msg *domain.MessageBody) (CancelDecision, error)
+
+// CancelOutcome is the outcome of a rider cancellation request.
+type CancelOutcome struct {
+	Message  domain.MessageBody
+	Decision CancelDecision
+	Response *domain.OrdersTransporterCancelAsyncConfirmResponse // 审核接口的响应，转人工时为空
+	Err      error
+}
+
+// AutoConfirmBeforeFetch is the policy accepting the cancellation while the goods are not fetched yet
+// (待接单、待取货、骑士到店), otherwise the request is escalated to a human.
+func AutoConfirmBeforeFetch(c *Client) CancelPolicy {
+	return func(ctx context.Context, msg *domain.MessageBody) (CancelDecision, error) {
+		resp, err := c.QueryOrderStatus(ctx, &domain.OrdersQueryRequest{OrderID: msg.OrderID})
+		if err != nil {
+			return CancelDecision{}, err
+		}
+		if resp.Result == nil {
+			return CancelDecision{Escalate: true}, nil
+		}
+		switch resp.Result.StatusCode {
+		case domain.OrderStatusWaitAccept, domain.OrderStatusWaitFetch, domain.OrderStatusArrived:
+			return CancelDecision{Confirm: true}, nil
+		default:
+			return CancelDecision{Escalate: true}, nil
+		}
+	}
+}
+
+// MessageReceiver receives the rider cancellation messages, decides with the policy
+// and answers them with OrderConfirmCancel.
+// See: http://newopen.imdada.cn/#/development/file/applicationCancel
+type MessageReceiver struct {
+	// OnOutcome is called with the outcome of every rider cancellation request.
+	OnOutcome func(ctx context.Context, outcome *CancelOutcome)
+	// Timeout bounds the policy and OrderConfirmCancel of a message, 5 seconds by default.
+	// The message fails when it is exceeded and the gateway sends it again.
+	Timeout time.Duration
+
+	client *Client
+	policy CancelPolicy
+}
+
+// NewMessageReceiver creates a MessageReceiver answering the rider cancellation requests with policy,
+// a nil policy escalates every request.
+func (c *Client) NewMessageReceiver(policy CancelPolicy) *MessageReceiver {
+	if policy == nil {
+		policy = escalate
+	}
+	return &MessageReceiver{client: c, policy: policy}
+}
+
+// escalate is the policy escalating every request to a human.
+func escalate(context.Context, *domain.MessageBody) (CancelDecision, error) {
+	return CancelDecision{Escalate: true}, nil
+}
+
+// Handle decodes and dispatches a message body, it returns the http status and the reply body.
+func (r *MessageReceiver) Handle(ctx context.Context, body []byte) (int, []byte) {
+	var head struct {
+		MessageType int `json:"messageType"`
+	}
+	if err := sonic.Unmarshal(body, &head); err != nil {
+		return r.reply(ctx, http.StatusBadRequest, err)
+	}
+	if head.MessageType != MessageTypeTransporterCancel {
+		r.client.log.CtxWarnf(ctx, "unknown message type: %d", head.MessageType)
+		return r.reply(ctx, http.StatusOK, nil)
+	}
+	var req domain.OrdersTransporterCancelAsyncRequest
+	if err := sonic.Unmarshal(body, &req); err != nil {
+		return r.reply(ctx, http.StatusBadRequest, err)
+	}
+	msg := req.MessageBody
+	outcome := r.decide(ctx, &msg)
+	if r.OnOutcome != nil {
+		r.OnOutcome(ctx, outcome)
+	}
+	return r.reply(ctx, http.StatusOK, outcome.Err)
+}
+
+// decide applies the policy and confirms or rejects the cancellation within the timeout.
+func (r *MessageReceiver) decide(ctx context.Context, msg *domain.MessageBody) *CancelOutcome {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultDecideTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	outcome := &CancelOutcome{Message: *msg}
+	if outcome.Decision, outcome.Err = r.policy(ctx, msg); outcome.Err != nil {
+		r.client.log.CtxErrorf(ctx, "cancel policy of order %s failed: %v", msg.OrderID, outcome.Err)
+		return outcome
+	}
+	if outcome.Decision.Escalate {
+		r.client.log.CtxInfof(ctx, "cancel request of order %s escalated, reason: %s", msg.OrderID, msg.CancelReason)
+		return outcome
+	}
+	confirm := domain.MessageBodyConfirm{
+		OrderID:      msg.OrderID,
+		DadaOrderID:  msg.DadaOrderID,
+		Imgs:         outcome.Decision.Imgs,
+		RejectReason: outcome.Decision.RejectReason,
+	}
+	if outcome.Decision.Confirm {
+		confirm.IsConfirm = 1
+	}
+	outcome.Response, outcome.Err = r.client.OrderConfirmCancel(ctx, &domain.OrdersTransporterCancelAsyncConfirmRequest{
+		MessageType: MessageTypeTransporterCancel,
+		MessageBody: confirm,
+	})
+	r.client.log.CtxInfof(ctx, "cancel request of order %s confirmed: %t, err: %v", msg.OrderID, outcome.Decision.Confirm, outcome.Err)
+	return outcome
+}
+
+// reply encodes the reply of a message.
+func (r *MessageReceiver) reply(ctx context.Context, code int, err error) (int, []byte) {
+	reply := domain.OrdersTransporterCancelAsyncResponse{Status: "ok"}
+	if err != nil {
+		r.client.log.CtxErrorf(ctx, "message failed: %v", err)
+		reply.Status = "fail"
+		if code == http.StatusOK {
+			code = http.StatusInternalServerError
+		}
+	}
+	data, _ := sonic.Marshal(&reply)
+	return code, data
+}
+
+// ServeHTTP implements http.Handler.
+func (r *MessageReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	serveHTTP(r, w, req)
+}
+
+// HertzHandler returns the hertz handler of the receiver.
+func (r *MessageReceiver) HertzHandler() app.HandlerFunc {
+	return hertzHandler(r)
+}
@@ -0,0 +1,170 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestConfirmRequestEncodesMessageBodyAsString(t *testing.T) {
+	req := domain.OrdersTransporterCancelAsyncConfirmRequest{
+		MessageType: dadago.MessageTypeTransporterCancel,
+		MessageBody: domain.MessageBodyConfirm{OrderID: "o1", DadaOrderID: 12, IsConfirm: 1},
+	}
+	data, err := sonic.Marshal(&req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		MessageBody string `json:"messageBody"`
+	}
+	if err = sonic.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("messageBody is not a JSON string: %s", data)
+	}
+	if want := `{"orderId":"o1","dadaOrderId":12,"isConfirm":1}`; raw.MessageBody != want {
+		t.Errorf("messageBody %s, want %s", raw.MessageBody, want)
+	}
+	var decoded domain.OrdersTransporterCancelAsyncConfirmRequest
+	if err = sonic.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.MessageBody; got.OrderID != "o1" || got.DadaOrderID != 12 || got.IsConfirm != 1 {
+		t.Errorf("decoded %+v, want %+v", decoded, req)
+	}
+}
+
+// message returns a rider cancellation message with the body encoded as a JSON string.
+func message(orderID string) string {
+	body := fmt.Sprintf(`{"orderId":%q,"dadaOrderId":1,"cancelReason":"商家未出餐"}`, orderID)
+	data, _ := sonic.MarshalString(body)
+	return `{"messageType":1,"messageBody":` + data + `}`
+}
+
+func TestMessageReceiverAutoConfirmBeforeFetch(t *testing.T) {
+	s, c := newTestClient(t, nil)
+	ctx := context.Background()
+	for _, id := range []string{"msg-wait", "msg-fetched"} {
+		if _, err := c.CreateOrder(ctx, newOrder(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range []func(context.Context, *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error){sandbox.AcceptOrder, sandbox.FetchOrder} {
+		if _, err = call(ctx, &domain.SandboxOrderRequest{OrderID: "msg-fetched"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	outcomes := make(map[string]*dadago.CancelOutcome)
+	r := c.NewMessageReceiver(dadago.AutoConfirmBeforeFetch(c))
+	r.OnOutcome = func(_ context.Context, o *dadago.CancelOutcome) {
+		outcomes[o.Message.OrderID] = o
+	}
+	for _, id := range []string{"msg-wait", "msg-fetched"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/message", strings.NewReader(message(id))))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"ok"`) {
+			t.Fatalf("%s: reply %d %s", id, w.Code, w.Body.String())
+		}
+	}
+
+	if o := outcomes["msg-wait"]; o == nil || !o.Decision.Confirm || o.Err != nil || o.Response == nil {
+		t.Errorf("msg-wait outcome %+v, want confirmed", o)
+	}
+	if o := outcomes["msg-fetched"]; o == nil || !o.Decision.Escalate || o.Response != nil {
+		t.Errorf("msg-fetched outcome %+v, want escalated", o)
+	}
+	confirms := s.Confirmations()
+	if len(confirms) != 1 || confirms[0].OrderID != "msg-wait" || confirms[0].IsConfirm != 1 {
+		t.Errorf("confirmations %+v, want one confirmation of msg-wait", confirms)
+	}
+}
+
+func TestCallbackReceiverForwardsMessages(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	var got string
+	r := c.NewCallbackReceiver()
+	r.Messages = c.NewMessageReceiver(func(_ context.Context, msg *domain.MessageBody) (dadago.CancelDecision, error) {
+		got = msg.OrderID
+		return dadago.CancelDecision{Escalate: true}, nil
+	})
+	code, reply := r.Handle(context.Background(), []byte(message("forwarded")))
+	if code != http.StatusOK || got != "forwarded" {
+		t.Errorf("reply %d %s, policy got %q", code, reply, got)
+	}
+}
+
+func TestMessageReceiverTimeout(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	r := c.NewMessageReceiver(func(ctx context.Context, _ *domain.MessageBody) (dadago.CancelDecision, error) {
+		<-ctx.Done()
+		return dadago.CancelDecision{}, ctx.Err()
+	})
+	r.Timeout = 50 * time.Millisecond
+	var outcome *dadago.CancelOutcome
+	r.OnOutcome = func(_ context.Context, o *dadago.CancelOutcome) {
+		outcome = o
+	}
+
+	start := time.Now()
+	code, reply := r.Handle(context.Background(), []byte(message("slow")))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Handle() returned after %s, want about the 50ms timeout", elapsed)
+	}
+	// The failed reply makes the gateway send the message again.
+	if code != http.StatusInternalServerError || !strings.Contains(string(reply), `"fail"`) {
+		t.Errorf("reply %d %s, want a failure", code, reply)
+	}
+	if outcome == nil || !errors.Is(outcome.Err, context.DeadlineExceeded) {
+		t.Errorf("outcome %+v, want context.DeadlineExceeded", outcome)
+	}
+}
+
+func TestMessageReceiverNilPolicyEscalates(t *testing.T) {
+	s, c := newTestClient(t, nil)
+	r := c.NewMessageReceiver(nil)
+	var outcome *dadago.CancelOutcome
+	r.OnOutcome = func(_ context.Context, o *dadago.CancelOutcome) {
+		outcome = o
+	}
+	if code, reply := r.Handle(context.Background(), []byte(message("nil-policy"))); code != http.StatusOK {
+		t.Fatalf("reply %d %s", code, reply)
+	}
+	if outcome == nil || !outcome.Decision.Escalate || outcome.Err != nil {
+		t.Errorf("outcome %+v, want escalated", outcome)
+	}
+	if confirms := s.Confirmations(); len(confirms) != 0 {
+		t.Errorf("confirmations %+v, want none", confirms)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Result labels of a request.
+const (
+	ResultSuccess   = "success"    // 请求成功
+	ResultAPIError  = "api_error"  // 达达返回失败
+	ResultHTTPError = "http_error" // 非200的http状态或非JSON响应
+	ResultError     = "error"      // 网络错误、超时、限流或熔断
+)
+
+// CodeNone is the code label of a call the gateway did not answer with a return code,
+// it differs from "-1", the system error code of the gateway.
+const CodeNone = "none"
+
+// Metrics receives the measurements of the client, implementations must be safe for concurrent use.
+// See the metrics package for a Prometheus implementation.
+type Metrics interface {
+	// ObserveRequest is called after every API call, code is the return code of the gateway,
+	// "0" on success and CodeNone when the gateway did not answer with a return code.
+	ObserveRequest(path, result, code string, latency time.Duration)
+	// ObserveRetry is called before a retry of the API call.
+	ObserveRetry(path string)
+	// ObserveRateLimitWait is called after a call waited for, or was rejected by, the rate limiter.
+	ObserveRateLimitWait(path string, wait time.Duration)
+	// ObserveCallback is called for every order status callback received.
+	ObserveCallback(orderStatus int)
+}
+
+// MetricsInterceptor records the request count, latency and return code of every call,
+// it is installed by WithMetrics.
+func MetricsInterceptor(m Metrics) Interceptor {
+	return func(ctx context.Context, inv *Invocation, next Handler) error {
+		start := time.Now()
+		err := next(ctx, inv)
+		result, code := resultOf(err)
+		m.ObserveRequest(inv.Path, result, code, time.Since(start))
+		return err
+	}
+}
+
+// resultOf returns the result label and the code label of err.
+func resultOf(err error) (result, code string) {
+	if err == nil {
+		return ResultSuccess, "0"
+	}
+	if apiErr, ok := asAPIError(err); ok {
+		return ResultAPIError, strconv.Itoa(apiErr.Code)
+	}
+	var httpErr *HTTPError
+	var decodeErr *DecodeError
+	if errors.As(err, &httpErr) || errors.As(err, &decodeErr) {
+		return ResultHTTPError, CodeNone
+	}
+	return ResultError, CodeNone
+}
@@ -0,0 +1,121 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+// Package metrics is the Prometheus implementation of dadago.Metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	dadago "github.com/houseme/imdadago"
+)
+
+// namespace is the namespace of the metrics.
+const namespace = "imdada"
+
+// PrometheusCollector records the measurements of the client as Prometheus metrics.
+// Register it with prometheus.MustRegister and pass it to dadago.WithMetrics.
+type PrometheusCollector struct {
+	requests      *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	retries       *prometheus.CounterVec
+	rateLimitWait *prometheus.HistogramVec
+	callbacks     *prometheus.CounterVec
+}
+
+var _ dadago.Metrics = (*PrometheusCollector)(nil)
+var _ prometheus.Collector = (*PrometheusCollector)(nil)
+
+// NewPrometheusCollector creates a PrometheusCollector, buckets are the latency buckets in seconds,
+// prometheus.DefBuckets is used when empty.
+func NewPrometheusCollector(buckets ...float64) *PrometheusCollector {
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	return &PrometheusCollector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of API calls by path, result and return code, code is \"none\" without a return code.",
+		}, []string{"path", "result", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of API calls by path, including retries.",
+			Buckets:   buckets,
+		}, []string{"path"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Total number of retried API calls by path.",
+		}, []string{"path"}),
+		rateLimitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time waited for the client side rate limiter by path.",
+			Buckets:   buckets,
+		}, []string{"path"}),
+		callbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "callbacks_total",
+			Help:      "Total number of order status callbacks by order status.",
+		}, []string{"order_status"}),
+	}
+}
+
+// ObserveRequest implements dadago.Metrics.
+func (c *PrometheusCollector) ObserveRequest(path, result, code string, latency time.Duration) {
+	c.requests.WithLabelValues(path, result, code).Inc()
+	c.latency.WithLabelValues(path).Observe(latency.Seconds())
+}
+
+// ObserveRetry implements dadago.Metrics.
+func (c *PrometheusCollector) ObserveRetry(path string) {
+	c.retries.WithLabelValues(path).Inc()
+}
+
+// ObserveRateLimitWait implements dadago.Metrics.
+func (c *PrometheusCollector) ObserveRateLimitWait(path string, wait time.Duration) {
+	c.rateLimitWait.WithLabelValues(path).Observe(wait.Seconds())
+}
+
+// ObserveCallback implements dadago.Metrics.
+func (c *PrometheusCollector) ObserveCallback(orderStatus int) {
+	c.callbacks.WithLabelValues(strconv.Itoa(orderStatus)).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (c *PrometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.retries.Describe(ch)
+	c.rateLimitWait.Describe(ch)
+	c.callbacks.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *PrometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.retries.Collect(ch)
+	c.rateLimitWait.Collect(ch)
+	c.callbacks.Collect(ch)
+}
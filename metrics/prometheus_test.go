@@ -0,0 +1,157 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const balancePath = "/api/balance/query"
+
+// newTestClient returns a client of a fake gateway recording into collector.
+func newTestClient(t *testing.T, collector *PrometheusCollector, opts ...dadago.Option) (*dadatest.Server, *dadago.Client) {
+	t.Helper()
+	s := dadatest.NewServer()
+	opts = append(append(s.ClientOptions(),
+		dadago.WithLogPath(t.TempDir()),
+		dadago.WithLevel(dadago.Level(hlog.LevelError)),
+		dadago.WithMetrics(collector),
+	), opts...)
+	c := dadago.New(context.Background(), opts...)
+	t.Cleanup(func() {
+		_ = c.Close()
+		s.Close()
+	})
+	return s, c
+}
+
+func TestPrometheusCollectorRequests(t *testing.T) {
+	collector := NewPrometheusCollector()
+	policy := dadago.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	s, c := newTestClient(t, collector, dadago.WithRetryPolicy(policy))
+	ctx := context.Background()
+	req := &domain.QueryBalanceRequest{Category: 1}
+
+	if _, err := c.QueryBalance(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	// Both attempts fail, the gateway answers neither with a return code.
+	s.InjectFault(balancePath, dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 2})
+	if _, err := c.QueryBalance(ctx, req); err == nil {
+		t.Fatal("QueryBalance() succeeded with a 502")
+	}
+	// -1 is the system error code of the gateway, not the lack of a code.
+	s.InjectFault(balancePath, dadatest.Fault{Code: dadatest.CodeSystem, Msg: "系统错误", Times: 2})
+	if _, err := c.QueryBalance(ctx, req); err == nil {
+		t.Fatal("QueryBalance() succeeded with a system error")
+	}
+
+	for _, tt := range []struct {
+		result, code string
+	}{
+		{dadago.ResultSuccess, "0"},
+		{dadago.ResultHTTPError, dadago.CodeNone},
+		{dadago.ResultAPIError, strconv.Itoa(dadatest.CodeSystem)},
+	} {
+		if got := testutil.ToFloat64(collector.requests.WithLabelValues(balancePath, tt.result, tt.code)); got != 1 {
+			t.Errorf("requests{result=%q,code=%q} = %v, want 1", tt.result, tt.code, got)
+		}
+	}
+	if n := testutil.CollectAndCount(collector.requests); n != 3 {
+		t.Errorf("%d request series, want 3", n)
+	}
+	if n := testutil.CollectAndCount(collector.latency); n != 1 {
+		t.Errorf("%d latency series, want 1", n)
+	}
+	if got := testutil.ToFloat64(collector.retries.WithLabelValues(balancePath)); got != 2 {
+		t.Errorf("retries = %v, want one per failed call", got)
+	}
+}
+
+func TestPrometheusCollectorRateLimitWait(t *testing.T) {
+	collector := NewPrometheusCollector()
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{Global: dadago.RateLimit{QPS: 50, Burst: 1}})
+	_, c := newTestClient(t, collector, dadago.WithRateLimiter(limiter))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first call takes the burst token, the others wait.
+	if n := testutil.CollectAndCount(collector.rateLimitWait, namespace+"_rate_limit_wait_seconds"); n != 1 {
+		t.Errorf("%d rate limit wait series, want the one of %s", n, balancePath)
+	}
+}
+
+func TestPrometheusCollectorCallbacks(t *testing.T) {
+	collector := NewPrometheusCollector()
+	_, c := newTestClient(t, collector)
+	r := c.NewCallbackReceiver()
+
+	for _, status := range []domain.OrderStatus{1, 4, 4} {
+		notify := domain.OrdersAsyncResponse{ClientID: "1", OrderID: "callback", OrderStatus: status, UpdateTime: time.Now().Unix()}
+		notify.Signature = dadago.CallbackSignature(notify.UpdateTime, notify.ClientID, notify.OrderID)
+		body, err := sonic.Marshal(&notify)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code, reply := r.Handle(context.Background(), body); code != http.StatusOK {
+			t.Fatalf("reply %d %s", code, reply)
+		}
+	}
+	// A callback with a bad signature is not counted.
+	if code, _ := r.Handle(context.Background(), []byte(`{"order_id":"callback","order_status":5,"signature":"bad"}`)); code != http.StatusUnauthorized {
+		t.Fatalf("reply %d to a bad signature", code)
+	}
+
+	for status, want := range map[string]float64{"1": 1, "4": 2} {
+		if got := testutil.ToFloat64(collector.callbacks.WithLabelValues(status)); got != want {
+			t.Errorf("callbacks{order_status=%s} = %v, want %v", status, got, want)
+		}
+	}
+	if n := testutil.CollectAndCount(collector.callbacks); n != 2 {
+		t.Errorf("%d callback series, want 2", n)
+	}
+}
+
+func TestPrometheusCollectorRegister(t *testing.T) {
+	collector := NewPrometheusCollector()
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatal(err)
+	}
+	collector.ObserveRequest(balancePath, dadago.ResultError, dadago.CodeNone, time.Millisecond)
+	if problems, err := testutil.GatherAndLint(registry); err != nil || len(problems) != 0 {
+		t.Errorf("lint problems %+v, %v", problems, err)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/houseme/imdadago/domain"
+	"github.com/houseme/imdadago/geo"
+)
+
+const (
+	// positionBatchSize is the most order ids of a transporter position query.
+	positionBatchSize = 50
+	// defaultPositionWorkers is the number of concurrent queries of QueryTransporterPositions.
+	defaultPositionWorkers = 4
+)
+
+// TransporterPosition is the position of the transporter of an order.
+type TransporterPosition struct {
+	OrderID  string    // 商家订单号
+	Location geo.Point // 骑士位置，GCJ-02 坐标
+	Name     string    // 骑士姓名
+	Phone    string    // 骑士电话
+}
+
+// BatchError is the error of the order ids of a failed batch.
+type BatchError struct {
+	OrderIDs []string
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("dadago: query transporter position of %d orders failed: %v", len(e.OrderIDs), e.Err)
+}
+
+// Unwrap returns the error of the batch.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchErrors is the errors of the failed batches.
+type BatchErrors []*BatchError
+
+// Error implements the error interface.
+func (e BatchErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// QueryTransporterPositions queries the transporter positions of any number of orders.
+// The ids are split into batches of 50 queried by at most workers goroutines, 4 when workers <= 0.
+// The positions of the successful batches are returned with a BatchErrors of the failed ones,
+// an order with an unparsable position is reported as a batch of its own.
+func (c *Client) QueryTransporterPositions(ctx context.Context, orderIDs []string, workers int) (map[string]*TransporterPosition, error) {
+	batches := positionBatches(orderIDs)
+	if workers <= 0 {
+		workers = defaultPositionWorkers
+	}
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		errs      BatchErrors
+		positions = make(map[string]*TransporterPosition, len(orderIDs))
+		jobs      = make(chan []string)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				found, failed := c.queryPositionBatch(ctx, batch)
+				mu.Lock()
+				for _, p := range found {
+					positions[p.OrderID] = p
+				}
+				errs = append(errs, failed...)
+				mu.Unlock()
+			}
+		}()
+	}
+	// cancel records the batches not dispatched when ctx is done.
+	cancel := func(rest [][]string) {
+		mu.Lock()
+		for _, batch := range rest {
+			errs = append(errs, &BatchError{OrderIDs: batch, Err: ctx.Err()})
+		}
+		mu.Unlock()
+	}
+dispatch:
+	for i, batch := range batches {
+		if ctx.Err() != nil {
+			cancel(batches[i:])
+			break
+		}
+		// The workers may all be busy with slow queries while ctx is done.
+		select {
+		case jobs <- batch:
+		case <-ctx.Done():
+			cancel(batches[i:])
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return positions, errs
+	}
+	return positions, nil
+}
+
+// queryPositionBatch queries the transporter positions of a batch.
+func (c *Client) queryPositionBatch(ctx context.Context, batch []string) ([]*TransporterPosition, []*BatchError) {
+	resp, err := c.QueryTransporterPosition(ctx, &domain.OrdersTransporterPositionRequest{OrderIDS: batch})
+	if err != nil {
+		return nil, []*BatchError{{OrderIDs: batch, Err: err}}
+	}
+	var (
+		found  = make([]*TransporterPosition, 0, len(resp.Result))
+		failed []*BatchError
+	)
+	for _, info := range resp.Result {
+		if info == nil {
+			continue
+		}
+		location, err := info.TransporterLocation()
+		if err != nil {
+			failed = append(failed, &BatchError{OrderIDs: []string{info.OrderID}, Err: err})
+			continue
+		}
+		found = append(found, &TransporterPosition{
+			OrderID:  info.OrderID,
+			Location: location,
+			Name:     info.TransporterName,
+			Phone:    info.TransporterPhone,
+		})
+	}
+	return found, failed
+}
+
+// positionBatches splits the distinct non-empty ids into batches of positionBatchSize.
+func positionBatches(orderIDs []string) [][]string {
+	seen := make(map[string]struct{}, len(orderIDs))
+	ids := make([]string, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	batches := make([][]string, 0, (len(ids)+positionBatchSize-1)/positionBatchSize)
+	for len(ids) > 0 {
+		n := positionBatchSize
+		if len(ids) < n {
+			n = len(ids)
+		}
+		batches = append(batches, ids[:n:n])
+		ids = ids[n:]
+	}
+	return batches
+}
@@ -0,0 +1,225 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+	"github.com/houseme/imdadago/geo"
+)
+
+const positionPath = "/api/order/transporter/position"
+
+// positionQueries records the batches sent to the gateway and the most concurrent ones.
+type positionQueries struct {
+	mu          sync.Mutex
+	batches     [][]string
+	inFlight    int
+	maxInFlight int
+}
+
+// interceptor returns the interceptor recording the queries.
+func (q *positionQueries) interceptor() dadago.Interceptor {
+	return func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		req, ok := inv.Request.(*domain.OrdersTransporterPositionRequest)
+		if !ok {
+			return next(ctx, inv)
+		}
+		q.mu.Lock()
+		q.batches = append(q.batches, req.OrderIDS)
+		if q.inFlight++; q.inFlight > q.maxInFlight {
+			q.maxInFlight = q.inFlight
+		}
+		q.mu.Unlock()
+		defer func() {
+			q.mu.Lock()
+			q.inFlight--
+			q.mu.Unlock()
+		}()
+		return next(ctx, inv)
+	}
+}
+
+// newPositionClient returns a client of a gateway with n accepted orders and their ids.
+func newPositionClient(t *testing.T, n int) (*dadatest.Server, *dadago.Client, *positionQueries, []string) {
+	t.Helper()
+	queries := &positionQueries{}
+	serverOpts := []dadatest.Option{dadatest.WithBalance(domain.Yuan(100000))}
+	s, c := newTestClient(t, serverOpts, dadago.WithInterceptors(queries.interceptor()))
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("position-%03d", i)
+		if _, err = c.CreateOrder(ctx, newOrder(id)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = sandbox.AcceptOrder(ctx, &domain.SandboxOrderRequest{OrderID: id}); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	return s, c, queries, ids
+}
+
+func TestQueryTransporterPositions(t *testing.T) {
+	_, c, queries, ids := newPositionClient(t, 120)
+	// Duplicates and empty ids are dropped, an unknown order has no position.
+	query := append(append([]string{"", ids[0], "unknown"}, ids...), ids[:10]...)
+
+	positions, err := c.QueryTransporterPositions(context.Background(), query, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != len(ids) {
+		t.Errorf("%d positions, want %d", len(positions), len(ids))
+	}
+	want := geo.New(31.230416, 121.473701, geo.GCJ02)
+	for _, id := range ids {
+		p := positions[id]
+		if p == nil || p.OrderID != id || p.Location != want || p.Name == "" {
+			t.Errorf("position of %s = %+v", id, p)
+		}
+	}
+
+	seen := make(map[string]int)
+	for _, batch := range queries.batches {
+		if len(batch) > 50 {
+			t.Errorf("batch of %d ids, want at most 50", len(batch))
+		}
+		for _, id := range batch {
+			seen[id]++
+		}
+	}
+	if len(queries.batches) != 3 {
+		t.Errorf("%d batches of %d distinct ids, want 3", len(queries.batches), len(ids)+1)
+	}
+	if len(seen) != len(ids)+1 || seen[""] != 0 {
+		t.Errorf("queried %d distinct ids, want %d without the empty one", len(seen), len(ids)+1)
+	}
+	for id, n := range seen {
+		if n != 1 {
+			t.Errorf("%s queried %d times", id, n)
+		}
+	}
+	if queries.maxInFlight > 2 {
+		t.Errorf("%d concurrent queries, want at most 2 workers", queries.maxInFlight)
+	}
+}
+
+func TestQueryTransporterPositionsBatchError(t *testing.T) {
+	s, c, queries, ids := newPositionClient(t, 120)
+	s.InjectFault(positionPath, dadatest.Fault{Code: dadatest.CodeSystem, Msg: "系统错误", Latency: 10 * time.Millisecond, Times: 1})
+
+	positions, err := c.QueryTransporterPositions(context.Background(), ids, 0)
+	var errs dadago.BatchErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("QueryTransporterPositions() = %v, want one failed batch", err)
+	}
+	failed := errs[0]
+	if !errors.Is(failed, dadago.ErrSystem) {
+		t.Errorf("batch error %v, want ErrSystem", failed)
+	}
+	if len(failed.OrderIDs) == 0 || len(failed.OrderIDs) > 50 {
+		t.Errorf("failed batch of %d ids", len(failed.OrderIDs))
+	}
+	if len(positions)+len(failed.OrderIDs) != len(ids) {
+		t.Errorf("%d positions and %d failed ids, want %d", len(positions), len(failed.OrderIDs), len(ids))
+	}
+	for _, id := range failed.OrderIDs {
+		if positions[id] != nil {
+			t.Errorf("position of the failed id %s", id)
+		}
+	}
+	if len(queries.batches) != 3 {
+		t.Errorf("%d batches, want 3", len(queries.batches))
+	}
+}
+
+func TestQueryTransporterPositionsCanceled(t *testing.T) {
+	_, c, queries, ids := newPositionClient(t, 60)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	positions, err := c.QueryTransporterPositions(ctx, ids, 1)
+	var errs dadago.BatchErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("QueryTransporterPositions() = %v, want two failed batches", err)
+	}
+	var failed int
+	for _, e := range errs {
+		if !errors.Is(e, context.Canceled) {
+			t.Errorf("batch error %v, want context.Canceled", e)
+		}
+		failed += len(e.OrderIDs)
+	}
+	if len(positions) != 0 || failed != len(ids) {
+		t.Errorf("%d positions and %d failed ids, want every id failed", len(positions), failed)
+	}
+	if len(queries.batches) != 0 {
+		t.Errorf("%d batches sent with a canceled context", len(queries.batches))
+	}
+}
+
+func TestQueryTransporterPositionsCanceledWhileBusy(t *testing.T) {
+	s, c, queries, ids := newPositionClient(t, 120)
+	// The only worker is busy with the first batch when ctx is canceled.
+	s.InjectFault(positionPath, dadatest.Fault{Latency: 500 * time.Millisecond, Times: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	positions, err := c.QueryTransporterPositions(ctx, ids, 1)
+	var errs dadago.BatchErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("QueryTransporterPositions() = %v, want the two batches not dispatched", err)
+	}
+	failed := 0
+	for _, e := range errs {
+		if !errors.Is(e, context.Canceled) {
+			t.Errorf("batch error %v, want context.Canceled", e)
+		}
+		failed += len(e.OrderIDs)
+	}
+	if len(positions)+failed != len(ids) {
+		t.Errorf("%d positions and %d failed ids, want %d", len(positions), failed, len(ids))
+	}
+	if len(queries.batches) != 1 {
+		t.Errorf("%d batches sent, want only the one in flight", len(queries.batches))
+	}
+}
+
+func TestQueryTransporterPositionsEmpty(t *testing.T) {
+	_, c := newTestClient(t, nil)
+	positions, err := c.QueryTransporterPositions(context.Background(), []string{"", ""}, 4)
+	if err != nil || len(positions) != 0 {
+		t.Errorf("QueryTransporterPositions() = %v, %v, want no positions", positions, err)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a call is rejected by the client side rate limiter.
+var ErrRateLimited = errors.New("dadago: rate limited")
+
+// RateLimitMode is the behaviour of the rate limiter when no token is available.
+type RateLimitMode int
+
+const (
+	// RateLimitWait blocks the call until a token is available or the context is done.
+	RateLimitWait RateLimitMode = iota
+	// RateLimitFailFast rejects the call with ErrRateLimited.
+	RateLimitFailFast
+)
+
+// RateLimit is the token bucket limit of the calls.
+type RateLimit struct {
+	QPS   float64 // 每秒请求数，小于等于0表示不限制
+	Burst int     // 突发请求数，默认为1
+}
+
+// RateLimitConfig is the configuration of RateLimiter.
+type RateLimitConfig struct {
+	Global RateLimit            // 整个 app key 的限制
+	Paths  map[string]RateLimit // 按接口路径的限制，如 "/api/order/addOrder"
+	Mode   RateLimitMode
+	// OnWait is called after a call waited for a token, or was rejected in fail fast mode.
+	OnWait func(ctx context.Context, path string, wait time.Duration, err error)
+}
+
+// RateLimiter is a token bucket rate limiter, configured globally and per API path.
+// It is safe for concurrent use and can be shared by several clients of the same app key.
+type RateLimiter struct {
+	cfg    RateLimitConfig
+	global *tokenBucket
+	paths  map[string]*tokenBucket
+}
+
+// NewRateLimiter creates a RateLimiter.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{
+		cfg:    cfg,
+		global: newTokenBucket(cfg.Global),
+		paths:  make(map[string]*tokenBucket, len(cfg.Paths)),
+	}
+	for path, limit := range cfg.Paths {
+		l.paths[path] = newTokenBucket(limit)
+	}
+	return l
+}
+
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = make(map[string]*RateLimiter)
+)
+
+// SharedRateLimiter returns the process wide RateLimiter of appKey, it is created with cfg on the first call.
+// Pass it to WithRateLimiter of every client using the same app key.
+func SharedRateLimiter(appKey string, cfg RateLimitConfig) *RateLimiter {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+	if l, ok := sharedLimiters[appKey]; ok {
+		return l
+	}
+	l := NewRateLimiter(cfg)
+	sharedLimiters[appKey] = l
+	return l
+}
+
+// Wait takes a token of the global and the path bucket, it returns the time waited.
+func (l *RateLimiter) Wait(ctx context.Context, path string) (time.Duration, error) {
+	now := time.Now()
+	buckets := []*tokenBucket{l.global, l.paths[path]}
+	var wait time.Duration
+	for _, b := range buckets {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	cancel := func() {
+		for _, b := range buckets {
+			b.cancel()
+		}
+	}
+	if l.cfg.Mode == RateLimitFailFast {
+		cancel()
+		return 0, ErrRateLimited
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(wait)) {
+		cancel()
+		return 0, ErrRateLimited
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		cancel()
+		return time.Since(now), ctx.Err()
+	case <-timer.C:
+		return wait, nil
+	}
+}
+
+// tokenBucket is a token bucket, a nil bucket never limits.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket, it returns nil when the limit is disabled.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.QPS <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.QPS,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait until the token is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token taken by reserve.
+func (b *tokenBucket) cancel() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
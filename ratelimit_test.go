@@ -0,0 +1,183 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+func TestRateLimiterFailFast(t *testing.T) {
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{
+		Global: dadago.RateLimit{QPS: 1, Burst: 1},
+		Mode:   dadago.RateLimitFailFast,
+	})
+	ctx := context.Background()
+	if wait, err := limiter.Wait(ctx, balancePath); wait != 0 || err != nil {
+		t.Fatalf("Wait() = %s, %v, want the burst token", wait, err)
+	}
+	start := time.Now()
+	if _, err := limiter.Wait(ctx, balancePath); !errors.Is(err, dadago.ErrRateLimited) {
+		t.Errorf("Wait() = %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want at once", elapsed)
+	}
+
+	// The rejected call is not sent.
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	_, c := newTestClient(t, nil, dadago.WithRateLimiter(limiter), dadago.WithTransport(rec))
+	if _, err := c.QueryBalance(ctx, &domain.QueryBalanceRequest{Category: 1}); !errors.Is(err, dadago.ErrRateLimited) {
+		t.Errorf("QueryBalance() = %v, want ErrRateLimited", err)
+	}
+	if n := len(rec.attempts(balancePath)); n != 0 {
+		t.Errorf("%d requests sent, want none", n)
+	}
+}
+
+func TestRateLimiterPathBucket(t *testing.T) {
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{
+		Global: dadago.RateLimit{QPS: 1000, Burst: 100},
+		Paths:  map[string]dadago.RateLimit{createOrderPath: {QPS: 1, Burst: 2}},
+		Mode:   dadago.RateLimitFailFast,
+	})
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.Wait(ctx, createOrderPath); err != nil {
+			t.Fatalf("Wait(%s) #%d = %v, want the burst of the path", createOrderPath, i+1, err)
+		}
+	}
+	if _, err := limiter.Wait(ctx, createOrderPath); !errors.Is(err, dadago.ErrRateLimited) {
+		t.Errorf("Wait(%s) = %v, want ErrRateLimited by the path bucket", createOrderPath, err)
+	}
+	// The other paths only share the global bucket.
+	for i := 0; i < 10; i++ {
+		if _, err := limiter.Wait(ctx, balancePath); err != nil {
+			t.Fatalf("Wait(%s) #%d = %v", balancePath, i+1, err)
+		}
+	}
+}
+
+func TestRateLimiterDeadlineGivesBackTokens(t *testing.T) {
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{Global: dadago.RateLimit{QPS: 10, Burst: 1}})
+	if _, err := limiter.Wait(context.Background(), balancePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next token comes in 100ms, after the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Wait(ctx, balancePath); !errors.Is(err, dadago.ErrRateLimited) {
+			t.Fatalf("Wait() = %v, want ErrRateLimited before the deadline", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want at once", elapsed)
+	}
+
+	// The rejected calls did not keep their tokens, the next one is not delayed by them.
+	time.Sleep(120 * time.Millisecond)
+	if wait, err := limiter.Wait(context.Background(), balancePath); wait != 0 || err != nil {
+		t.Errorf("Wait() = %s, %v, want the refilled token", wait, err)
+	}
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	cfg := dadago.RateLimitConfig{Global: dadago.RateLimit{QPS: 1, Burst: 1}, Mode: dadago.RateLimitFailFast}
+	// The limiters are process wide, every run uses its own app key.
+	appKey := fmt.Sprintf("shared-%d", time.Now().UnixNano())
+	first := dadago.SharedRateLimiter(appKey, cfg)
+	// The configuration of the later calls is ignored.
+	second := dadago.SharedRateLimiter(appKey, dadago.RateLimitConfig{})
+	if first != second {
+		t.Fatal("SharedRateLimiter() returned two limiters of the same app key")
+	}
+	if other := dadago.SharedRateLimiter(appKey+"-other", cfg); other == first {
+		t.Fatal("SharedRateLimiter() shared a limiter between app keys")
+	}
+	if _, err := first.Wait(context.Background(), balancePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Wait(context.Background(), balancePath); !errors.Is(err, dadago.ErrRateLimited) {
+		t.Errorf("Wait() = %v, want the bucket emptied by the first limiter", err)
+	}
+}
+
+// waitMetrics records the rate limit waits.
+type waitMetrics struct {
+	mu    sync.Mutex
+	waits map[string][]time.Duration
+}
+
+func (m *waitMetrics) ObserveRequest(string, string, string, time.Duration) {}
+
+func (m *waitMetrics) ObserveRetry(string) {}
+
+func (m *waitMetrics) ObserveCallback(int) {}
+
+func (m *waitMetrics) ObserveRateLimitWait(path string, wait time.Duration) {
+	m.mu.Lock()
+	m.waits[path] = append(m.waits[path], wait)
+	m.mu.Unlock()
+}
+
+func TestRateLimiterOnWait(t *testing.T) {
+	type call struct {
+		path string
+		wait time.Duration
+		err  error
+	}
+	var calls []call
+	limiter := dadago.NewRateLimiter(dadago.RateLimitConfig{
+		Global: dadago.RateLimit{QPS: 20, Burst: 1},
+		OnWait: func(_ context.Context, path string, wait time.Duration, err error) {
+			calls = append(calls, call{path, wait, err})
+		},
+	})
+	m := &waitMetrics{waits: make(map[string][]time.Duration)}
+	_, c := newTestClient(t, nil, dadago.WithRateLimiter(limiter), dadago.WithMetrics(m))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first call takes the burst token, the others wait about 50ms.
+	if len(calls) != 2 {
+		t.Fatalf("OnWait called %d times, want 2", len(calls))
+	}
+	for _, w := range calls {
+		if w.path != balancePath || w.wait <= 0 || w.wait > 50*time.Millisecond || w.err != nil {
+			t.Errorf("OnWait(%s, %s, %v), want a wait of at most 50ms", w.path, w.wait, w.err)
+		}
+	}
+	if waits := m.waits[balancePath]; len(waits) != 2 || waits[0] != calls[0].wait || waits[1] != calls[1].wait {
+		t.Errorf("ObserveRateLimitWait() got %v, want the waits of OnWait", waits)
+	}
+}
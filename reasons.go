@@ -0,0 +1,146 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// defaultRefreshTimeout bounds a refresh of the cancel reasons when the client has no TimeOut.
+const defaultRefreshTimeout = 10 * time.Second
+
+// reasonCache caches the cancel reasons of the gateway.
+// The mutex only guards the fields, the gateway is queried by a single refresh at a time.
+type reasonCache struct {
+	mu      sync.Mutex
+	items   []*domain.CancelReasonItem
+	expires time.Time
+	refresh *reasonRefresh // 进行中的刷新
+}
+
+// reasonRefresh is a refresh of the cancel reasons, done is closed when it ends.
+type reasonRefresh struct {
+	done  chan struct{}
+	items []*domain.CancelReasonItem
+	err   error
+}
+
+// CancelReasons returns the cancel reasons of the gateway, they are cached for CancelReasonsTTL.
+// An expired list is returned at once while it is refreshed in the background, and kept when the refresh fails.
+// Without a cached list the callers wait for the single refresh or their context.
+func (c *Client) CancelReasons(ctx context.Context) ([]*domain.CancelReasonItem, error) {
+	c.reasons.mu.Lock()
+	items, fresh := c.reasons.items, time.Now().Before(c.reasons.expires)
+	if items != nil && fresh {
+		c.reasons.mu.Unlock()
+		return items, nil
+	}
+	r := c.reasons.refresh
+	if r == nil {
+		r = &reasonRefresh{done: make(chan struct{})}
+		c.reasons.refresh = r
+		go c.refreshCancelReasons(ctx, r)
+	}
+	c.reasons.mu.Unlock()
+
+	if items != nil {
+		return items, nil
+	}
+	select {
+	case <-r.done:
+		return r.items, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refreshCancelReasons runs the refresh r and stores the fetched list.
+// The refresh outlives the caller starting it but is bounded by the TimeOut of the client,
+// so a hung gateway can not block the later refreshes.
+func (c *Client) refreshCancelReasons(ctx context.Context, r *reasonRefresh) {
+	timeout := c.op.TimeOut
+	if timeout <= 0 {
+		timeout = defaultRefreshTimeout
+	}
+	ctx, cancel := context.WithTimeout(detach(ctx), timeout)
+	defer cancel()
+	r.items, r.err = c.fetchCancelReasons(ctx)
+	c.reasons.mu.Lock()
+	if r.err == nil {
+		c.reasons.items, c.reasons.expires = r.items, time.Now().Add(c.op.CancelReasonsTTL)
+	} else if c.reasons.items != nil {
+		c.log.CtxWarnf(ctx, "refresh cancel reasons failed, use the stale list: %v", r.err)
+	}
+	c.reasons.refresh = nil
+	c.reasons.mu.Unlock()
+	close(r.done)
+}
+
+// RefreshCancelReasons fetches the cancel reasons from the gateway and replaces the cached list.
+func (c *Client) RefreshCancelReasons(ctx context.Context) ([]*domain.CancelReasonItem, error) {
+	items, err := c.fetchCancelReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.reasons.mu.Lock()
+	c.reasons.items, c.reasons.expires = items, time.Now().Add(c.op.CancelReasonsTTL)
+	c.reasons.mu.Unlock()
+	return items, nil
+}
+
+// fetchCancelReasons queries the cancel reasons.
+func (c *Client) fetchCancelReasons(ctx context.Context) ([]*domain.CancelReasonItem, error) {
+	resp, err := c.QueryCancelReasons(ctx, &domain.CancelReasonsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return []*domain.CancelReasonItem{}, nil
+	}
+	return resp.Result, nil
+}
+
+// detachedContext keeps the values of its parent, e.g. the trace, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+// detach returns a context outliving ctx, the refresh does not belong to the caller starting it.
+func detach(ctx context.Context) context.Context {
+	return detachedContext{Context: ctx}
+}
+
+// Deadline implements context.Context.
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done implements context.Context.
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err implements context.Context.
+func (detachedContext) Err() error {
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+)
+
+const reasonsPath = "/api/order/cancel/reasons"
+
+// reasonCalls counts the cancel reasons queries, done receives their errors.
+type reasonCalls struct {
+	started int32
+	done    chan error
+}
+
+// interceptor returns the interceptor counting the queries.
+func (r *reasonCalls) interceptor() dadago.Interceptor {
+	return func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		if inv.Path != reasonsPath {
+			return next(ctx, inv)
+		}
+		atomic.AddInt32(&r.started, 1)
+		err := next(ctx, inv)
+		r.done <- err
+		return err
+	}
+}
+
+func newReasonsClient(t *testing.T, ttl time.Duration) (*dadatest.Server, *dadago.Client, *reasonCalls) {
+	t.Helper()
+	calls := &reasonCalls{done: make(chan error, 16)}
+	s, c := newTestClient(t, nil, dadago.WithCancelReasonsTTL(ttl), dadago.WithInterceptors(calls.interceptor()))
+	return s, c, calls
+}
+
+func TestCancelReasonsSingleRefresh(t *testing.T) {
+	s, c, calls := newReasonsClient(t, time.Hour)
+	s.InjectFault(reasonsPath, dadatest.Fault{Latency: 100 * time.Millisecond, Times: 1})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if items, err := c.CancelReasons(context.Background()); err != nil || len(items) == 0 {
+				t.Errorf("CancelReasons() = %d items, %v", len(items), err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&calls.started); n != 1 {
+		t.Errorf("%d queries of the gateway, want 1", n)
+	}
+	if _, err := c.CancelReasons(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&calls.started); n != 1 {
+		t.Errorf("%d queries of the gateway with a fresh cache, want 1", n)
+	}
+}
+
+func TestCancelReasonsStaleWhileSlow(t *testing.T) {
+	s, c, calls := newReasonsClient(t, 10*time.Millisecond)
+	cached, err := c.CancelReasons(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-calls.done
+	time.Sleep(20 * time.Millisecond)
+
+	s.InjectFault(reasonsPath, dadatest.Fault{Latency: 500 * time.Millisecond, Code: dadatest.CodeSystem, Msg: "系统错误", Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 4; i++ {
+		items, err := c.CancelReasons(ctx)
+		if err != nil || len(items) != len(cached) {
+			t.Fatalf("CancelReasons() while the gateway is slow = %d items, %v, want the stale list", len(items), err)
+		}
+	}
+	if ctx.Err() != nil {
+		t.Fatal("the stale list waited for the gateway")
+	}
+	if err = <-calls.done; err == nil {
+		t.Fatal("the injected fault did not fail the refresh")
+	}
+	if n := atomic.LoadInt32(&calls.started); n != 2 {
+		t.Errorf("%d queries of the gateway, want 2", n)
+	}
+	// The failed refresh keeps the stale list.
+	if items, err := c.CancelReasons(context.Background()); err != nil || len(items) != len(cached) {
+		t.Errorf("CancelReasons() after a failed refresh = %d items, %v", len(items), err)
+	}
+}
+
+func TestCancelReasonsWaitHonoursContext(t *testing.T) {
+	s, c, calls := newReasonsClient(t, time.Hour)
+	s.InjectFault(reasonsPath, dadatest.Fault{Latency: 300 * time.Millisecond, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.CancelReasons(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("CancelReasons() = %v, want context.DeadlineExceeded", err)
+	}
+	// The refresh does not belong to the caller and fills the cache.
+	if err := <-calls.done; err != nil {
+		t.Fatal(err)
+	}
+	if items, err := c.CancelReasons(context.Background()); err != nil || len(items) == 0 {
+		t.Errorf("CancelReasons() = %d items, %v", len(items), err)
+	}
+	if n := atomic.LoadInt32(&calls.started); n != 1 {
+		t.Errorf("%d queries of the gateway, want 1", n)
+	}
+}
+
+func TestCancelReasonsRefreshTimesOut(t *testing.T) {
+	calls := &reasonCalls{done: make(chan error, 16)}
+	s, c := newTestClient(t, nil, dadago.WithTimeOut(100*time.Millisecond), dadago.WithInterceptors(calls.interceptor()))
+	s.InjectFault(reasonsPath, dadatest.Fault{Latency: time.Second, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.CancelReasons(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("CancelReasons() = %v, want context.DeadlineExceeded", err)
+	}
+	// The hung refresh ends with the timeout of the client and the next one succeeds.
+	select {
+	case err := <-calls.done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("refresh ended with %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("the refresh did not time out")
+	}
+	// The failed refresh is done shortly after its query, a call before may still wait for it.
+	deadline := time.Now().Add(time.Second)
+	for {
+		items, err := c.CancelReasons(context.Background())
+		if err == nil && len(items) > 0 {
+			break
+		}
+		if !errors.Is(err, context.DeadlineExceeded) || time.Now().After(deadline) {
+			t.Fatalf("CancelReasons() after the timeout = %d items, %v", len(items), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := atomic.LoadInt32(&calls.started); n != 2 {
+		t.Errorf("%d queries of the gateway, want the hung one and one more", n)
+	}
+}
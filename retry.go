@@ -0,0 +1,163 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy is the retry policy of the API calls.
+// Only the read-only APIs are retried, a mutating API such as CreateOrder is retried
+// only when the context is marked with ContextWithRetry.
+type RetryPolicy struct {
+	MaxAttempts int                  // 最大尝试次数，包含首次请求
+	BaseDelay   time.Duration        // 首次重试的等待时间，之后按指数递增
+	MaxDelay    time.Duration        // 单次等待时间上限
+	Jitter      float64              // 随机抖动比例，取值 0~1
+	RetryIf     func(err error) bool // 判断错误是否可重试，为空时使用 IsRetryable
+}
+
+// DefaultRetryPolicy returns the default retry policy, 3 attempts starting at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// readOnlyPaths are the APIs without side effects, they are safe to retry.
+var readOnlyPaths = map[string]bool{
+	queryBalance:         true,
+	shopQuery:            true,
+	cityCodeList:         true,
+	orderStatusQuery:     true,
+	transporterPosition:  true,
+	orderDeliverFeeQuery: true,
+	orderCancelReasons:   true,
+}
+
+type retryKey struct{}
+
+// ContextWithRetry marks the calls made with ctx as retryable, including the mutating APIs.
+// Use it only when the request is idempotent on the merchant side, e.g. CreateOrder with a fixed origin_id.
+func ContextWithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// IsRetryable reports whether err is a temporary failure: a network error,
+// a 5xx or 429 http status, or a retryable return code of the gateway.
+// A call rejected by the rate limiter or the circuit breaker is not retried.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if apiErr, ok := asAPIError(err); ok {
+		return apiErr.Retryable()
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var decodeErr *DecodeError
+	return !errors.As(err, &decodeErr)
+}
+
+// shouldRetry reports whether the call of path may be retried.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, path string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+	if readOnlyPaths[path] {
+		return true
+	}
+	retry, _ := ctx.Value(retryKey{}).(bool)
+	return retry
+}
+
+// retryable reports whether err may be retried.
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.RetryIf != nil {
+		return p.RetryIf(err)
+	}
+	return IsRetryable(err)
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// backoff returns the wait time before the next attempt, attempt starts at 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		jitterMu.Lock()
+		r := jitterRand.Float64()
+		jitterMu.Unlock()
+		delay = time.Duration(float64(delay) * (1 - p.Jitter + 2*p.Jitter*r))
+	}
+	return delay
+}
+
+// doRetry does the request with the retry policy, each attempt is signed with a fresh timestamp.
+func (c *Client) doRetry(ctx context.Context, inv *Invocation) (data []byte, err error) {
+	path, policy := inv.Path, c.op.RetryPolicy
+	if !policy.shouldRetry(ctx, path) {
+		inv.Attempts++
+		return c.doRequest(ctx, path, inv.Envelope, inv.Header)
+	}
+	for attempt := 1; ; attempt++ {
+		inv.Attempts++
+		if data, err = c.doRequest(ctx, path, inv.Envelope, inv.Header); err == nil {
+			return data, nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return nil, err
+		}
+		delay := policy.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		c.log.CtxWarnf(ctx, "%s attempt %d failed: %v, retry after %s", path, attempt, err, delay)
+		if c.op.Metrics != nil {
+			c.op.Metrics.ObserveRetry(path)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
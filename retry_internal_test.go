@@ -0,0 +1,57 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+	// A large attempt does not overflow.
+	if got := policy.backoff(100); got != time.Second {
+		t.Errorf("backoff(100) = %s, want MaxDelay", got)
+	}
+
+	unbounded := RetryPolicy{BaseDelay: 10 * time.Millisecond}
+	if got := unbounded.backoff(4); got != 80*time.Millisecond {
+		t.Errorf("backoff(4) without MaxDelay = %s, want 80ms", got)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := (&RetryPolicy{BaseDelay: policy.BaseDelay, MaxDelay: policy.MaxDelay}).backoff(attempt)
+		low := time.Duration(float64(base) * (1 - policy.Jitter))
+		high := time.Duration(float64(base) * (1 + policy.Jitter))
+		for i := 0; i < 100; i++ {
+			if got := policy.backoff(attempt); got < low || got > high {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", attempt, got, low, high)
+			}
+		}
+	}
+}
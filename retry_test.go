@@ -0,0 +1,186 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const (
+	retrySecret     = "retry-secret"
+	createOrderPath = "/api/order/addOrder"
+	statusQueryPath = "/api/order/status/query"
+)
+
+// attempt is a request sent by the transport.
+type attempt struct {
+	url      string
+	envelope domain.Request
+}
+
+// recordingTransport records a copy of every request it sends.
+type recordingTransport struct {
+	next dadago.Transport
+
+	mu   sync.Mutex
+	sent []attempt
+}
+
+// Do implements dadago.Transport.
+func (t *recordingTransport) Do(ctx context.Context, req *dadago.TransportRequest) (*dadago.TransportResponse, error) {
+	t.mu.Lock()
+	t.sent = append(t.sent, attempt{url: req.URL, envelope: *req.Envelope})
+	t.mu.Unlock()
+	return t.next.Do(ctx, req)
+}
+
+// attempts returns the envelopes sent to path.
+func (t *recordingTransport) attempts(path string) []domain.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []domain.Request
+	for _, a := range t.sent {
+		if strings.HasSuffix(a.url, path) {
+			out = append(out, a.envelope)
+		}
+	}
+	return out
+}
+
+func newRetryClient(t *testing.T, policy dadago.RetryPolicy) (*dadatest.Server, *dadago.Client, *recordingTransport) {
+	t.Helper()
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	serverOpts := []dadatest.Option{dadatest.WithAppSecret(retrySecret)}
+	s, c := newTestClient(t, serverOpts, dadago.WithTransport(rec), dadago.WithRetryPolicy(policy))
+	return s, c, rec
+}
+
+// fastRetry retries at once, so the tests do not wait.
+func fastRetry() dadago.RetryPolicy {
+	return dadago.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+}
+
+func TestRetryReadOnlyByDefault(t *testing.T) {
+	s, c, rec := newRetryClient(t, fastRetry())
+	ctx := context.Background()
+	if _, err := c.CreateOrder(ctx, newOrder("retry-1")); err != nil {
+		t.Fatal(err)
+	}
+	s.InjectFault(statusQueryPath, dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 1})
+
+	resp, err := c.QueryOrderStatus(ctx, &domain.OrdersQueryRequest{OrderID: "retry-1"})
+	if err != nil || resp.Result == nil || resp.Result.OrderID != "retry-1" {
+		t.Fatalf("QueryOrderStatus() = %+v, %v", resp, err)
+	}
+	if n := len(rec.attempts(statusQueryPath)); n != 2 {
+		t.Errorf("%d attempts, want 2", n)
+	}
+
+	// A code that is not retryable is returned at once.
+	s.InjectFault(statusQueryPath, dadatest.Fault{Code: dadatest.CodeInvalidParam, Msg: "参数错误", Times: 1})
+	if _, err = c.QueryOrderStatus(ctx, &domain.OrdersQueryRequest{OrderID: "retry-1"}); !errors.Is(err, dadago.ErrInvalidParam) {
+		t.Errorf("QueryOrderStatus() = %v, want ErrInvalidParam", err)
+	}
+	if n := len(rec.attempts(statusQueryPath)); n != 3 {
+		t.Errorf("%d attempts in total, want 3", n)
+	}
+}
+
+func TestRetryCreateOrderNeedsContextWithRetry(t *testing.T) {
+	s, c, rec := newRetryClient(t, fastRetry())
+
+	s.InjectFault(createOrderPath, dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 1})
+	_, err := c.CreateOrder(context.Background(), newOrder("retry-2"))
+	var httpErr *dadago.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("CreateOrder() = %v, want the 502", err)
+	}
+	if n := len(rec.attempts(createOrderPath)); n != 1 {
+		t.Errorf("%d attempts without ContextWithRetry, want 1", n)
+	}
+
+	s.InjectFault(createOrderPath, dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 1})
+	if _, err = c.CreateOrder(dadago.ContextWithRetry(context.Background()), newOrder("retry-2")); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(rec.attempts(createOrderPath)); n != 3 {
+		t.Errorf("%d attempts in total, want 1 + 2 with ContextWithRetry", n)
+	}
+	if _, found := s.Order("retry-2"); !found {
+		t.Error("the retried order was not created")
+	}
+}
+
+func TestRetrySignsEveryAttempt(t *testing.T) {
+	// The timestamp has a precision of one second, wait long enough to see it change.
+	policy := dadago.RetryPolicy{MaxAttempts: 2, BaseDelay: 1100 * time.Millisecond}
+	s, c, rec := newRetryClient(t, policy)
+	s.InjectFault(balancePath, dadatest.Fault{HTTPStatus: http.StatusServiceUnavailable, Times: 1})
+
+	if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+		t.Fatal(err)
+	}
+	attempts := rec.attempts(balancePath)
+	if len(attempts) != 2 {
+		t.Fatalf("%d attempts, want 2", len(attempts))
+	}
+	first, second := attempts[0], attempts[1]
+	if second.Timestamp <= first.Timestamp {
+		t.Errorf("timestamps %d then %d, want a fresh one", first.Timestamp, second.Timestamp)
+	}
+	if first.Signature == second.Signature {
+		t.Error("the retry reused the signature")
+	}
+	for i := range attempts {
+		if want := dadago.Sign(retrySecret, &attempts[i]); attempts[i].Signature != want {
+			t.Errorf("attempt %d signature %s, want %s", i+1, attempts[i].Signature, want)
+		}
+	}
+}
+
+func TestRetryStopsBeforeDeadline(t *testing.T) {
+	policy := dadago.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}
+	s, c, rec := newRetryClient(t, policy)
+	s.InjectFault(balancePath, dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.QueryBalance(ctx, &domain.QueryBalanceRequest{Category: 1})
+	var httpErr *dadago.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("QueryBalance() = %v, want the 502 of the only attempt", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("returned after %s, want at once: the next delay passes the deadline", elapsed)
+	}
+	if n := len(rec.attempts(balancePath)); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// ErrNotSandbox is returned when the simulation APIs are used against the production gateway.
+var ErrNotSandbox = errors.New("dadago: simulation APIs are not available on the production gateway")
+
+// Sandbox exposes the order simulation APIs of the test environment.
+// 测试环境模拟订单状态 url: http://newopen.imdada.cn/#/development/file/acceptOrder
+type Sandbox struct {
+	c *Client
+}
+
+// Sandbox returns the simulation APIs of the client, it refuses to run against the production gateway.
+func (c *Client) Sandbox() (*Sandbox, error) {
+	u, err := url.Parse(c.op.Gateway)
+	if err != nil {
+		return nil, err
+	}
+	if prod, _ := url.Parse(gateway); strings.EqualFold(u.Hostname(), prod.Hostname()) {
+		return nil, ErrNotSandbox
+	}
+	return &Sandbox{c: c}, nil
+}
+
+// AcceptOrder simulates a transporter accepting the order.
+// 模拟接受订单
+func (s *Sandbox) AcceptOrder(ctx context.Context, req *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderAccept, req)
+}
+
+// FetchOrder simulates a transporter fetching the goods.
+// 模拟完成取货
+func (s *Sandbox) FetchOrder(ctx context.Context, req *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderFetch, req)
+}
+
+// FinishOrder simulates a transporter finishing the delivery.
+// 模拟完成订单
+func (s *Sandbox) FinishOrder(ctx context.Context, req *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderFinish, req)
+}
+
+// CancelOrder simulates the cancellation of the order.
+// 模拟取消订单
+func (s *Sandbox) CancelOrder(ctx context.Context, req *domain.SandboxOrderCancelRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderCancelRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderCancel, req)
+}
+
+// ExpireOrder simulates the expiration of the order.
+// 模拟订单过期
+func (s *Sandbox) ExpireOrder(ctx context.Context, req *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderExpire, req)
+}
+
+// AbnormalBackOrder simulates a delivery exception, the goods are returned to the shop.
+// 模拟妥投异常之物品返回
+func (s *Sandbox) AbnormalBackOrder(ctx context.Context, req *domain.SandboxOrderRequest) (*domain.SandboxOrderResponse, error) {
+	return Invoke[domain.SandboxOrderRequest, domain.SandboxOrderResponse](ctx, s.c, sandboxOrderAbnormalBack, req)
+}
@@ -0,0 +1,98 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+const offlineSecret = "offline-app-secret"
+
+// stubTransport answers every request with a success, it never reaches the network.
+type stubTransport struct{}
+
+// Do implements dadago.Transport.
+func (stubTransport) Do(context.Context, *dadago.TransportRequest) (*dadago.TransportResponse, error) {
+	return &dadago.TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"status":"success","code":0,"msg":"成功"}`)}, nil
+}
+
+// newOfflineClient returns a client sending its requests to rec.
+func newOfflineClient(t *testing.T, rec *recordingTransport, opts ...dadago.Option) *dadago.Client {
+	t.Helper()
+	opts = append([]dadago.Option{
+		dadago.WithAppKey("offline-app-key"),
+		dadago.WithAppSecret(offlineSecret),
+		dadago.WithLogPath(t.TempDir()),
+		dadago.WithLevel(dadago.Level(hlog.LevelError)),
+		dadago.WithTransport(rec),
+	}, opts...)
+	c := dadago.New(context.Background(), opts...)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestSandboxRefusesProduction(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []dadago.Option
+	}{
+		{name: "default gateway"},
+		{name: "explicit gateway", opts: []dadago.Option{dadago.WithGateway("https://NEWOPEN.imdada.cn/")}},
+		{name: "gateway after sandbox", opts: []dadago.Option{dadago.WithSandbox(), dadago.WithGateway("https://newopen.imdada.cn")}},
+	}
+	for _, tt := range tests {
+		rec := &recordingTransport{next: stubTransport{}}
+		c := newOfflineClient(t, rec, tt.opts...)
+		if sandbox, err := c.Sandbox(); !errors.Is(err, dadago.ErrNotSandbox) || sandbox != nil {
+			t.Errorf("%s: Sandbox() = %v, %v, want ErrNotSandbox", tt.name, sandbox, err)
+		}
+	}
+}
+
+func TestWithSandbox(t *testing.T) {
+	rec := &recordingTransport{next: stubTransport{}}
+	c := newOfflineClient(t, rec, dadago.WithSandbox())
+	sandbox, err := c.Sandbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sandbox.AcceptOrder(context.Background(), &domain.SandboxOrderRequest{OrderID: "sandbox-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"https://newopen.qa.imdada.cn/api/order/accept", "https://newopen.qa.imdada.cn" + balancePath}
+	if len(rec.sent) != len(want) {
+		t.Fatalf("%d requests sent, want %d", len(rec.sent), len(want))
+	}
+	for i, a := range rec.sent {
+		if a.url != want[i] {
+			t.Errorf("request %d sent to %s, want %s", i+1, a.url, want[i])
+		}
+	}
+}
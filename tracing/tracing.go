@@ -0,0 +1,297 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+// Package tracing is the OpenTelemetry instrumentation of ImDaDaGo.
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+const (
+	// instrumentationName is the name of the tracer.
+	instrumentationName = "github.com/houseme/imdadago/tracing"
+	// callbackSpanName is the name of the callback spans.
+	callbackSpanName = "dada.callback"
+)
+
+// Attribute keys of the spans.
+const (
+	ShopNoKey      = attribute.Key("dada.shop_no")
+	OriginIDKey    = attribute.Key("dada.origin_id")
+	ClientIDKey    = attribute.Key("dada.client_id")
+	CodeKey        = attribute.Key("dada.code")
+	RetryCountKey  = attribute.Key("dada.retry_count")
+	OrderStatusKey = attribute.Key("dada.order_status")
+	MessageTypeKey = attribute.Key("dada.message_type")
+	HTTPStatusKey  = attribute.Key("http.status_code")
+)
+
+// options is the configuration of Tracer.
+type options struct {
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// Option the option is a Tracer option.
+type Option func(o *options)
+
+// WithTracerProvider sets the tracer provider, the global one is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.TracerProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context into the request headers,
+// the global one is used by default.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.Propagator = propagator
+	}
+}
+
+// Tracer creates the spans of the API calls and the callbacks.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// New creates a Tracer.
+func New(opts ...Option) *Tracer {
+	op := options{}
+	for _, option := range opts {
+		option(&op)
+	}
+	if op.TracerProvider == nil {
+		op.TracerProvider = otel.GetTracerProvider()
+	}
+	if op.Propagator == nil {
+		op.Propagator = otel.GetTextMapPropagator()
+	}
+	return &Tracer{
+		tracer:     op.TracerProvider.Tracer(instrumentationName),
+		propagator: op.Propagator,
+	}
+}
+
+// Interceptor returns the interceptor creating a client span named after the path of every API call,
+// pass it to dadago.WithInterceptors.
+func (t *Tracer) Interceptor() dadago.Interceptor {
+	return func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		ctx, span := t.tracer.Start(ctx, inv.Path, trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		span.SetAttributes(requestAttributes(inv.Request)...)
+		if inv.Header == nil {
+			inv.Header = make(map[string]string)
+		}
+		t.propagator.Inject(ctx, propagation.MapCarrier(inv.Header))
+
+		err := next(ctx, inv)
+		if inv.Attempts > 1 {
+			span.SetAttributes(RetryCountKey.Int(inv.Attempts - 1))
+		}
+		if err == nil {
+			span.SetAttributes(CodeKey.Int(0))
+			return nil
+		}
+
+		var apiErr *dadago.APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttributes(CodeKey.Int(apiErr.Code))
+		}
+		var httpErr *dadago.HTTPError
+		if errors.As(err, &httpErr) {
+			span.SetAttributes(HTTPStatusKey.Int(httpErr.StatusCode))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+}
+
+// HTTPHandler wraps the net/http handler of a dadago.CallbackReceiver or dadago.MessageReceiver.
+// The server span covers the whole request, its parent is the trace context extracted from the request headers,
+// and the callback handlers get the span in their context.
+func (t *Tracer) HTTPHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := t.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := t.tracer.Start(ctx, callbackSpanName, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		if body, err := io.ReadAll(r.Body); err == nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			span.SetAttributes(callbackAttributes(body)...)
+		}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+		endCallback(span, sw.status)
+	})
+}
+
+// HertzHandler wraps the hertz handler of a dadago.CallbackReceiver or dadago.MessageReceiver,
+// the span is the same as the one of HTTPHandler.
+func (t *Tracer) HertzHandler(next app.HandlerFunc) app.HandlerFunc {
+	return func(ctx context.Context, rc *app.RequestContext) {
+		ctx = t.propagator.Extract(ctx, hertzCarrier{header: &rc.Request.Header})
+		ctx, span := t.tracer.Start(ctx, callbackSpanName, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		span.SetAttributes(callbackAttributes(rc.Request.Body())...)
+		next(ctx, rc)
+		endCallback(span, rc.Response.StatusCode())
+	}
+}
+
+// StartCallback starts a server span for an order status callback, the caller must end the span.
+// HTTPHandler and HertzHandler start the span for the whole request and are preferred.
+func (t *Tracer) StartCallback(ctx context.Context, notify *domain.OrdersAsyncResponse) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, callbackSpanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+		OriginIDKey.String(notify.OrderID),
+		ClientIDKey.String(notify.ClientID),
+		OrderStatusKey.Int(int(notify.OrderStatus)),
+	))
+}
+
+// requestAttributes returns the shop_no and origin_id attributes of a request.
+func requestAttributes(req any) []attribute.KeyValue {
+	var shopNo, originID string
+	switch r := req.(type) {
+	case *domain.OrdersCreateRequest:
+		shopNo, originID = r.ShopNo, r.OriginID
+	case *domain.DeliverFeeQueryRequest:
+		shopNo, originID = r.ShopNo, r.OriginID
+	case *domain.OrdersQueryRequest:
+		originID = r.OrderID
+	case *domain.OrdersCancelRequest:
+		originID = r.OrderID
+	case *domain.OrdersAddTipRequest:
+		originID = r.OrderID
+	case *domain.OrdersAddAppointRequest:
+		shopNo, originID = r.ShopNo, r.OrderID
+	case *domain.OrdersCancelAppointRequest:
+		originID = r.OrderID
+	case *domain.OrdersConfirmGoodsRequest:
+		originID = r.OrderID
+	case *domain.OrdersFetchCodeModifyRequest:
+		originID = r.OriginID
+	case *domain.ComplaintRequest:
+		originID = r.OrderID
+	case *domain.OrdersAppointTransporterRequest:
+		shopNo = r.ShopNo
+	case *domain.QueryBalanceRequest:
+		shopNo = r.ShopNo
+	case *domain.RechargeRequest:
+		shopNo = r.ShopNo
+	case *domain.ShopQueryRequest:
+		shopNo = r.OriginShopID
+	case *domain.ShopUpdateRequest:
+		shopNo = r.OriginShopID
+	}
+	var attrs []attribute.KeyValue
+	if shopNo != "" {
+		attrs = append(attrs, ShopNoKey.String(shopNo))
+	}
+	if originID != "" {
+		attrs = append(attrs, OriginIDKey.String(originID))
+	}
+	return attrs
+}
+
+// callbackAttributes returns the attributes of a callback or message body, an undecodable body has none.
+func callbackAttributes(body []byte) []attribute.KeyValue {
+	var notify struct {
+		OrderID     string `json:"order_id"`
+		ClientID    string `json:"client_id"`
+		OrderStatus int    `json:"order_status"`
+		MessageType int    `json:"messageType"`
+	}
+	if err := sonic.Unmarshal(body, &notify); err != nil {
+		return nil
+	}
+	if notify.MessageType != 0 {
+		return []attribute.KeyValue{MessageTypeKey.Int(notify.MessageType)}
+	}
+	return []attribute.KeyValue{
+		OriginIDKey.String(notify.OrderID),
+		ClientIDKey.String(notify.ClientID),
+		OrderStatusKey.Int(notify.OrderStatus),
+	}
+}
+
+// endCallback records the http status of the reply, a 5xx reply is an error.
+func endCallback(span trace.Span, status int) {
+	span.SetAttributes(HTTPStatusKey.Int(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
+// statusWriter records the status written by the wrapped handler.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// hertzCarrier adapts the hertz request headers to propagation.TextMapCarrier.
+type hertzCarrier struct {
+	header *protocol.RequestHeader
+}
+
+// Get returns the value of the key.
+func (c hertzCarrier) Get(key string) string {
+	return c.header.Get(key)
+}
+
+// Set sets the value of the key.
+func (c hertzCarrier) Set(key, value string) {
+	c.header.Set(key, value)
+}
+
+// Keys returns the keys of the headers.
+func (c hertzCarrier) Keys() []string {
+	var keys []string
+	c.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
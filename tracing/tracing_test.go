@@ -0,0 +1,283 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const (
+	testShopNo    = "trace-shop"
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	remoteParent  = "00f067aa0ba902b7"
+)
+
+// newTestTracer returns a Tracer exporting to an in-memory exporter.
+func newTestTracer() (*Tracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return New(WithTracerProvider(provider), WithPropagator(propagation.TraceContext{})), exporter
+}
+
+// newTestClient returns a client of a fake gateway instrumented by the tracer,
+// the headers of every call are sent to headers.
+func newTestClient(t *testing.T, tracer *Tracer, headers chan<- map[string]string, opts ...dadago.Option) (*dadatest.Server, *dadago.Client) {
+	t.Helper()
+	s := dadatest.NewServer()
+	s.AddShop(domain.ShopQueryItem{OriginShopID: testShopNo})
+	record := func(ctx context.Context, inv *dadago.Invocation, next dadago.Handler) error {
+		if headers != nil {
+			headers <- inv.Header
+		}
+		return next(ctx, inv)
+	}
+	opts = append(append(s.ClientOptions(),
+		dadago.WithLogPath(t.TempDir()),
+		dadago.WithLevel(dadago.Level(hlog.LevelError)),
+		dadago.WithInterceptors(tracer.Interceptor(), record),
+	), opts...)
+	c := dadago.New(context.Background(), opts...)
+	t.Cleanup(func() {
+		_ = c.Close()
+		s.Close()
+	})
+	return s, c
+}
+
+// attributes returns the attributes of the span as a map.
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// onlySpan returns the only span of the exporter.
+func onlySpan(t *testing.T, exporter *tracetest.InMemoryExporter) tracetest.SpanStub {
+	t.Helper()
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	return spans[0]
+}
+
+func newOrder(originID string) *domain.OrdersCreateRequest {
+	return &domain.OrdersCreateRequest{
+		ShopNo:        testShopNo,
+		OriginID:      originID,
+		CargoPrice:    domain.Yuan(10),
+		ReceiverLat:   31.23,
+		ReceiverLng:   121.47,
+		ReceiverPhone: "13800000000",
+	}
+}
+
+func TestInterceptorSpan(t *testing.T) {
+	tracer, exporter := newTestTracer()
+	headers := make(chan map[string]string, 1)
+	_, c := newTestClient(t, tracer, headers)
+
+	if _, err := c.CreateOrder(context.Background(), newOrder("trace-1")); err != nil {
+		t.Fatal(err)
+	}
+	span := onlySpan(t, exporter)
+	if span.Name != "/api/order/addOrder" {
+		t.Errorf("span name %q, want the path", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span kind %v, want client", span.SpanKind)
+	}
+	attrs := attributes(span)
+	if got := attrs[ShopNoKey].AsString(); got != testShopNo {
+		t.Errorf("shop_no %q, want %q", got, testShopNo)
+	}
+	if got := attrs[OriginIDKey].AsString(); got != "trace-1" {
+		t.Errorf("origin_id %q, want trace-1", got)
+	}
+	if got, ok := attrs[CodeKey]; !ok || got.AsInt64() != 0 {
+		t.Errorf("code %v, want 0", got.Emit())
+	}
+	if _, ok := attrs[RetryCountKey]; ok {
+		t.Error("retry_count set without retries")
+	}
+
+	header := <-headers
+	want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+	if got := header["traceparent"]; got != want {
+		t.Errorf("traceparent %q, want %q", got, want)
+	}
+}
+
+func TestInterceptorSpanRetryAndCode(t *testing.T) {
+	tracer, exporter := newTestTracer()
+	s, c := newTestClient(t, tracer, nil, dadago.WithRetryPolicy(dadago.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	s.InjectFault("/api/balance/query", dadatest.Fault{HTTPStatus: http.StatusBadGateway, Times: 2})
+	if _, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	attrs := attributes(onlySpan(t, exporter))
+	if got := attrs[RetryCountKey].AsInt64(); got != 2 {
+		t.Errorf("retry_count %d, want 2", got)
+	}
+	exporter.Reset()
+
+	s.InjectFault("/api/order/addOrder", dadatest.Fault{Code: dadatest.CodeInvalidParam, Msg: "参数错误", Times: 1})
+	if _, err := c.CreateOrder(context.Background(), newOrder("trace-2")); err == nil {
+		t.Fatal("want the injected error")
+	}
+	span := onlySpan(t, exporter)
+	if got := attributes(span)[CodeKey].AsInt64(); got != dadatest.CodeInvalidParam {
+		t.Errorf("code %d, want %d", got, dadatest.CodeInvalidParam)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("status %v, want error", span.Status.Code)
+	}
+}
+
+// signedCallback returns the body of a signed order status callback.
+func signedCallback(t *testing.T, orderID string, status domain.OrderStatus) []byte {
+	t.Helper()
+	notify := domain.OrdersAsyncResponse{ClientID: "client-" + orderID, OrderID: orderID, OrderStatus: status, UpdateTime: time.Now().Unix()}
+	notify.Signature = dadago.CallbackSignature(notify.UpdateTime, notify.ClientID, notify.OrderID)
+	body, err := sonic.Marshal(&notify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// checkCallbackSpan checks the callback span is a child of the remote parent and the handler got its context.
+func checkCallbackSpan(t *testing.T, exporter *tracetest.InMemoryExporter, handled trace.SpanContext, status int) {
+	t.Helper()
+	span := onlySpan(t, exporter)
+	if span.Name != callbackSpanName || span.SpanKind != trace.SpanKindServer {
+		t.Errorf("span %q of kind %v, want a %s server span", span.Name, span.SpanKind, callbackSpanName)
+	}
+	if got := span.Parent.TraceID().String(); got != remoteTraceID || !span.Parent.IsRemote() {
+		t.Errorf("parent trace %s remote %t, want the extracted %s", got, span.Parent.IsRemote(), remoteTraceID)
+	}
+	if got := span.Parent.SpanID().String(); got != remoteParent {
+		t.Errorf("parent span %s, want %s", got, remoteParent)
+	}
+	if handled.SpanID() != span.SpanContext.SpanID() {
+		t.Error("the callback handler did not get the callback span")
+	}
+	attrs := attributes(span)
+	if got := attrs[OriginIDKey].AsString(); got != "cb-1" {
+		t.Errorf("origin_id %q, want cb-1", got)
+	}
+	if got := attrs[OrderStatusKey].AsInt64(); got != int64(domain.OrderStatusFinished) {
+		t.Errorf("order_status %d, want %d", got, domain.OrderStatusFinished)
+	}
+	if got := attrs[HTTPStatusKey].AsInt64(); got != int64(status) {
+		t.Errorf("http.status_code %d, want %d", got, status)
+	}
+}
+
+func newTestReceiver(t *testing.T, handled *trace.SpanContext) *dadago.CallbackReceiver {
+	t.Helper()
+	c := dadago.New(context.Background(), dadago.WithLogPath(t.TempDir()), dadago.WithLevel(dadago.Level(hlog.LevelError)))
+	t.Cleanup(func() { _ = c.Close() })
+	r := c.NewCallbackReceiver()
+	r.OnFinished = func(ctx context.Context, _ *domain.OrdersAsyncResponse) error {
+		*handled = trace.SpanContextFromContext(ctx)
+		return nil
+	}
+	return r
+}
+
+const traceparent = "00-" + remoteTraceID + "-" + remoteParent + "-01"
+
+func TestHTTPHandler(t *testing.T) {
+	tracer, exporter := newTestTracer()
+	var handled trace.SpanContext
+	handler := tracer.HTTPHandler(newTestReceiver(t, &handled))
+
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(string(signedCallback(t, "cb-1", domain.OrderStatusFinished))))
+	req.Header.Set("traceparent", traceparent)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	checkCallbackSpan(t, exporter, handled, http.StatusOK)
+}
+
+func TestHTTPHandlerError(t *testing.T) {
+	tracer, exporter := newTestTracer()
+	var handled trace.SpanContext
+	r := newTestReceiver(t, &handled)
+	r.OnFinished = func(context.Context, *domain.OrdersAsyncResponse) error {
+		return context.DeadlineExceeded
+	}
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(string(signedCallback(t, "cb-1", domain.OrderStatusFinished))))
+	w := httptest.NewRecorder()
+	tracer.HTTPHandler(r).ServeHTTP(w, req)
+
+	span := onlySpan(t, exporter)
+	if span.Status.Code != codes.Error {
+		t.Errorf("status %v, want error", span.Status.Code)
+	}
+	if got := attributes(span)[HTTPStatusKey].AsInt64(); got != http.StatusInternalServerError {
+		t.Errorf("http.status_code %d, want 500", got)
+	}
+}
+
+func TestHertzHandler(t *testing.T) {
+	tracer, exporter := newTestTracer()
+	var handled trace.SpanContext
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.POST("/callback", tracer.HertzHandler(newTestReceiver(t, &handled).HertzHandler()))
+
+	body := signedCallback(t, "cb-1", domain.OrderStatusFinished)
+	w := ut.PerformRequest(engine, http.MethodPost, "/callback",
+		&ut.Body{Body: strings.NewReader(string(body)), Len: len(body)},
+		ut.Header{Key: "traceparent", Value: traceparent},
+		ut.Header{Key: "Content-Length", Value: strconv.Itoa(len(body))},
+	)
+	if got := w.Result().StatusCode(); got != http.StatusOK {
+		t.Fatalf("status %d: %s", got, w.Result().Body())
+	}
+	checkCallbackSpan(t, exporter, handled, http.StatusOK)
+}
@@ -0,0 +1,155 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/hertz/pkg/app/client"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+
+	"github.com/houseme/imdadago/domain"
+)
+
+// Transport sends a signed request envelope to the gateway and returns the raw response.
+// Implementations must be safe for concurrent use.
+type Transport interface {
+	Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error)
+}
+
+// TransportRequest is a signed request to the gateway.
+type TransportRequest struct {
+	URL       string            // 完整请求地址，gateway + path
+	UserAgent []byte            // User-Agent 请求头
+	Header    map[string]string // 附加的请求头
+	Envelope  *domain.Request   // 已签名的请求报文
+}
+
+// TransportResponse is the raw response of the gateway.
+type TransportResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// HertzTransport is the Transport based on the hertz http client, it is the default transport.
+type HertzTransport struct {
+	client *client.Client
+}
+
+// NewHertzTransport creates a Transport from an existing hertz client.
+func NewHertzTransport(c *client.Client) *HertzTransport {
+	return &HertzTransport{client: c}
+}
+
+// newHertzTransport creates the pooled hertz transport from the client options.
+func newHertzTransport(op options) (*HertzTransport, error) {
+	opts := []config.ClientOption{
+		client.WithTLSConfig(newTLSConfig(op)),
+		client.WithDialTimeout(op.TimeOut),
+	}
+	if op.MaxConnsPerHost > 0 {
+		opts = append(opts, client.WithMaxConnsPerHost(op.MaxConnsPerHost))
+	}
+	if op.IdleTimeout > 0 {
+		opts = append(opts, client.WithMaxIdleConnDuration(op.IdleTimeout))
+	}
+	if op.ReadTimeout > 0 {
+		opts = append(opts, client.WithClientReadTimeout(op.ReadTimeout))
+	}
+	if op.WriteTimeout > 0 {
+		opts = append(opts, client.WithWriteTimeout(op.WriteTimeout))
+	}
+	if op.Dialer != nil {
+		opts = append(opts, client.WithDialer(op.Dialer))
+	}
+	hertz, err := client.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if op.Proxy != nil {
+		hertz.SetProxy(op.Proxy)
+	}
+	return NewHertzTransport(hertz), nil
+}
+
+// newTLSConfig returns the tls config of the gateway, the server certificate is verified by default.
+func newTLSConfig(op options) *tls.Config {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if op.TLSConfig != nil {
+		cfg = op.TLSConfig.Clone()
+	}
+	if op.InsecureSkipVerify {
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg
+}
+
+// Do sends the request with the hertz client.
+// The hertz client does not watch ctx, so the deadline of ctx is passed to it as the request deadline;
+// a cancellation without deadline only stops a request that is not sent yet.
+func (t *HertzTransport) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := sonic.Marshal(req.Envelope)
+	if err != nil {
+		return nil, err
+	}
+	request := &protocol.Request{}
+	request.SetBody(jsonBytes)
+	request.Header.SetContentTypeBytes([]byte("application/json"))
+	request.Header.Set("accept", "application/json")
+	request.SetRequestURI(req.URL)
+	request.Header.SetMethod(consts.MethodPost)
+	request.Header.SetUserAgentBytes(req.UserAgent)
+	for k, v := range req.Header {
+		request.Header.Set(k, v)
+	}
+
+	response := &protocol.Response{}
+	if deadline, ok := ctx.Deadline(); ok {
+		err = t.client.DoDeadline(ctx, request, response, deadline)
+	} else {
+		err = t.client.Do(ctx, request, response)
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+			return nil, context.DeadlineExceeded
+		}
+		return nil, err
+	}
+	return &TransportResponse{
+		StatusCode: response.StatusCode(),
+		Body:       response.Body(),
+	}, nil
+}
+
+// Close closes the idle connections of the pool.
+func (t *HertzTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
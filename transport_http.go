@@ -0,0 +1,80 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/bytedance/sonic"
+)
+
+// HTTPTransport is the Transport based on the standard library net/http client.
+// It can be used with any http.RoundTripper middleware.
+type HTTPTransport struct {
+	client *http.Client
+}
+
+// NewHTTPTransport creates a Transport from a net/http client, http.DefaultClient is used when c is nil.
+func NewHTTPTransport(c *http.Client) *HTTPTransport {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &HTTPTransport{client: c}
+}
+
+// Do sends the request with the net/http client.
+func (t *HTTPTransport) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	jsonBytes, err := sonic.Marshal(req.Envelope)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(jsonBytes))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", string(req.UserAgent))
+	for k, v := range req.Header {
+		request.Header.Set(k, v)
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &TransportResponse{
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}, nil
+}
+
+// Close closes the idle connections of the client.
+func (t *HTTPTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
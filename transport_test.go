@@ -0,0 +1,152 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/dadatest"
+	"github.com/houseme/imdadago/domain"
+)
+
+const balancePath = "/api/balance/query"
+
+func TestHertzTransportHonoursDeadline(t *testing.T) {
+	s, c := newTestClient(t, nil)
+	s.InjectFault(balancePath, dadatest.Fault{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.QueryBalance(ctx, &domain.QueryBalanceRequest{Category: 1})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("QueryBalance() = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 600*time.Millisecond {
+		t.Errorf("QueryBalance() returned after %s, want about the 200ms deadline", elapsed)
+	}
+
+	// A done context is not sent at all.
+	s.ClearFaults()
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+	if _, err = c.QueryBalance(done, &domain.QueryBalanceRequest{Category: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("QueryBalance() with a canceled context = %v, want context.Canceled", err)
+	}
+}
+
+// closeTransport records whether it was closed.
+type closeTransport struct {
+	dadago.Transport
+	closed bool
+}
+
+// Close implements io.Closer.
+func (t *closeTransport) Close() error {
+	t.closed = true
+	return nil
+}
+
+func TestHTTPTransport(t *testing.T) {
+	transport := &closeTransport{Transport: dadago.NewHTTPTransport(nil)}
+	s, c := newTestClient(t, nil, dadago.WithTransport(transport))
+	s.SetBalance(domain.Yuan(42))
+
+	resp, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result == nil || resp.Result.DeliverBalance != domain.Yuan(42) {
+		t.Errorf("QueryBalance() = %+v, want a balance of 42", resp.Result)
+	}
+	// The transport of the caller outlives the client.
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if transport.closed {
+		t.Error("Close() closed the transport passed with WithTransport")
+	}
+}
+
+// newTLSGateway starts a gateway with a self-signed certificate answering every call with a balance.
+func newTLSGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","code":0,"msg":"成功","result":{"deliverBalance":1}}`))
+	}))
+	// The failed handshakes are expected.
+	s.Config.ErrorLog = log.New(io.Discard, "", 0)
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestHertzTransportTLS(t *testing.T) {
+	s := newTLSGateway(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(s.Certificate())
+
+	tests := []struct {
+		name    string
+		opts    []dadago.Option
+		wantErr bool
+	}{
+		{name: "verify by default", wantErr: true},
+		{name: "insecure skip verify", opts: []dadago.Option{dadago.WithInsecureSkipVerify()}},
+		{name: "root CA", opts: []dadago.Option{dadago.WithTLSConfig(&tls.Config{RootCAs: roots})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]dadago.Option{
+				dadago.WithGateway(s.URL),
+				dadago.WithAppKey("tls-app-key"),
+				dadago.WithAppSecret("tls-app-secret"),
+				dadago.WithLogPath(t.TempDir()),
+				dadago.WithLevel(dadago.Level(hlog.LevelFatal)),
+			}, tt.opts...)
+			c := dadago.New(context.Background(), opts...)
+			defer c.Close()
+
+			resp, err := c.QueryBalance(context.Background(), &domain.QueryBalanceRequest{Category: 1})
+			if tt.wantErr {
+				var certErr x509.UnknownAuthorityError
+				if !errors.As(err, &certErr) {
+					t.Errorf("QueryBalance() = %v, want an unknown authority error", err)
+				}
+				return
+			}
+			if err != nil || resp.Result == nil || resp.Result.DeliverBalance != domain.Yuan(1) {
+				t.Errorf("QueryBalance() = %+v, %v", resp, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ *  Copyright `IMDaDa-Go` Author(https://houseme.github.io/imdadago/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/imdadago.
+ */
+
+package dadago_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	dadago "github.com/houseme/imdadago"
+	"github.com/houseme/imdadago/domain"
+)
+
+// invalidOrder returns an order failing the origin_mark_no rule.
+func invalidOrder(originID string) *domain.OrdersCreateRequest {
+	order := newOrder(originID)
+	order.OriginMarkNo = "M-001"
+	return order
+}
+
+// orderCalls are the calls validated with WithRequestValidation.
+var orderCalls = []struct {
+	name string
+	path string
+	call func(ctx context.Context, c *dadago.Client, order *domain.OrdersCreateRequest) error
+}{
+	{"CreateOrder", createOrderPath, func(ctx context.Context, c *dadago.Client, order *domain.OrdersCreateRequest) error {
+		_, err := c.CreateOrder(ctx, order)
+		return err
+	}},
+	{"ReCreateOrder", "/api/order/reAddOrder", func(ctx context.Context, c *dadago.Client, order *domain.OrdersCreateRequest) error {
+		_, err := c.ReCreateOrder(ctx, order)
+		return err
+	}},
+	{"QueryDeliverFee", "/api/order/queryDeliverFee", func(ctx context.Context, c *dadago.Client, order *domain.OrdersCreateRequest) error {
+		req := domain.DeliverFeeQueryRequest(*order)
+		_, err := c.QueryDeliverFee(ctx, &req)
+		return err
+	}},
+}
+
+func TestRequestValidation(t *testing.T) {
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	_, c := newTestClient(t, nil, dadago.WithTransport(rec), dadago.WithRequestValidation())
+	for _, tt := range orderCalls {
+		err := tt.call(context.Background(), c, invalidOrder("invalid-"+tt.name))
+		var errs domain.ValidationErrors
+		if !errors.As(err, &errs) || errs.Field("origin_mark_no") == nil {
+			t.Errorf("%s() = %v, want a ValidationErrors of origin_mark_no", tt.name, err)
+		}
+		if n := len(rec.attempts(tt.path)); n != 0 {
+			t.Errorf("%s sent %d requests, want none", tt.name, n)
+		}
+	}
+}
+
+func TestRequestValidationDisabled(t *testing.T) {
+	rec := &recordingTransport{next: dadago.NewHTTPTransport(&http.Client{})}
+	_, c := newTestClient(t, nil, dadago.WithTransport(rec))
+	for _, tt := range orderCalls {
+		// The gateway decides on the order, the client does not reject it.
+		err := tt.call(context.Background(), c, invalidOrder("unchecked-"+tt.name))
+		var errs domain.ValidationErrors
+		if errors.As(err, &errs) {
+			t.Errorf("%s() = %v, want no validation without WithRequestValidation", tt.name, err)
+		}
+		if n := len(rec.attempts(tt.path)); n != 1 {
+			t.Errorf("%s sent %d requests, want 1", tt.name, n)
+		}
+	}
+}